@@ -0,0 +1,69 @@
+package golaredge
+
+import "context"
+
+// API is the set of endpoint methods of Client, so that code using the API can be tested against a fake such as golaredgetest.Fake.
+// The helpers built on top of the endpoints (ranges, batches, pagination) are methods of Client only.
+type API interface {
+	SiteDataAPI
+	SiteEquipmentAPI
+	AccountListAPI
+	MetersAPI
+	SensorsAPI
+	VersionsAPI
+}
+
+// SiteDataAPI is the Site Data API.
+type SiteDataAPI interface {
+	GetSiteList(ctx context.Context, params SiteListParams) (SiteListResponse, error)
+	GetSite(ctx context.Context, params SiteParams) (SiteDetailsResponse, error)
+	GetSiteDataStartAndEndDates(ctx context.Context, params SiteDataStartAndEndDatesParams) (SiteDataPeriodResponse, error)
+	GetSiteDataStartAndEndDatesBulk(ctx context.Context, params SiteDataStartAndEndDatesBulkParams) (SiteDataPeriodBulkResponse, error)
+	GetSiteEnergy(ctx context.Context, params SiteEnergyParams) (SiteEnergyResponse, error)
+	GetSiteEnergyBulk(ctx context.Context, params SiteEnergyBulkParams) (SiteEnergyBulkResponse, error)
+	GetSiteEnergyTimePeriod(ctx context.Context, params SiteEnergyTimePeriodParams) (SiteEnergyTimePeriodResponse, error)
+	GetSiteEnergyTimePeriodBulk(ctx context.Context, params SiteEnergyTimePeriodBulkParams) (SiteEnergyTimePeriodBulkResponse, error)
+	GetSitePower(ctx context.Context, params SitePowerParams) (SitePowerResponse, error)
+	GetSitePowerBulk(ctx context.Context, params SitePowerBulkParams) (SitePowerBulkResponse, error)
+	GetSiteOverview(ctx context.Context, params SiteOverviewParams) (SiteOverviewResponse, error)
+	GetSiteOverviewBulk(ctx context.Context, params SiteOverviewBulkParams) (SiteOverviewBulkResponse, error)
+	GetSitePowerDetailed(ctx context.Context, params SitePowerDetailedParams) (SitePowerDetailedResponse, error)
+	GetSiteEnergyDetailed(ctx context.Context, params SiteEnergyDetailedParams) (SiteEnergyDetailedResponse, error)
+	GetSitePowerFlow(ctx context.Context, params SitePowerFlowParams) (SitePowerFlowResponse, error)
+	GetStorageInformation(ctx context.Context, params StorageInformationParams) (StorageInformationResponse, error)
+	GetSiteImage(ctx context.Context, params SiteImageParams) ([]byte, error)
+	GetSiteEnvironmentalBenefits(ctx context.Context, params SiteEnvironmentalBenefitsParams) (SiteEnvironmentalBenefitsResponse, error)
+	GetInstallerImage(ctx context.Context, params SiteImageParams) ([]byte, error)
+}
+
+// SiteEquipmentAPI is the Site Equipment API.
+type SiteEquipmentAPI interface {
+	GetComponentsList(ctx context.Context, params ComponentsListParams) (ComponentsListResponse, error)
+	GetInventory(ctx context.Context, params InventoryParams) (InventoryResponse, error)
+	GetInverterTechnicalData(ctx context.Context, params InverterTechnicalDataParams) (InverterTechnicalDataResponse, error)
+	GetEquipmentChangeLog(ctx context.Context, params EquipmentChangeLogParams) (EquipmentChangeLogResponse, error)
+}
+
+// AccountListAPI is the Account List API.
+type AccountListAPI interface {
+	GetAccountList(ctx context.Context, params AccountListParams) (AccountListResponse, error)
+}
+
+// MetersAPI is the Meters API.
+type MetersAPI interface {
+	GetMetersData(ctx context.Context, params MetersDataParams) (MetersDataResponse, error)
+}
+
+// SensorsAPI is the Sensors API.
+type SensorsAPI interface {
+	GetSensorsList(ctx context.Context, params SensorsListParams) (SensorsListResponse, error)
+	GetSensorData(ctx context.Context, params SensorDataParams) (SensorDataResponse, error)
+}
+
+// VersionsAPI is the API Versions endpoints, they need no api key.
+type VersionsAPI interface {
+	GetCurrentVersion(ctx context.Context) (CurrentVersionResponse, error)
+	GetSupportedVersion(ctx context.Context) (SupportedVersionResponse, error)
+}
+
+var _ API = (*Client)(nil)
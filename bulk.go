@@ -0,0 +1,239 @@
+package golaredge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+)
+
+const (
+	// MaxBulkSiteIds is the maximum number of sites the API accepts in a single bulk request.
+	MaxBulkSiteIds int = 100
+
+	// maxBulkSiteIdsLength keeps the comma separated site ids short enough for proxies limiting the url length.
+	maxBulkSiteIdsLength int = 1000
+
+	// bulkConcurrency is the number of batches executed at the same time.
+	bulkConcurrency int = DefaultMaxConcurrent
+)
+
+// BulkResult holds the merged results of the batches of a bulk request, keyed by site id.
+// Every requested site is either in Values or in Errors.
+type BulkResult[T any] struct {
+	Values map[int]T
+	Errors map[int]error
+}
+
+// batchSiteIds removes invalid and duplicated site ids and splits the others in batches the API accepts.
+func batchSiteIds(siteIds []int, errs map[int]error) [][]int {
+	batches := [][]int{}
+	batch := []int{}
+	length := 0
+	seen := map[int]bool{}
+
+	for i := range siteIds {
+		siteId := siteIds[i]
+
+		if siteId < 0 {
+			errs[siteId] = ErrInvalidSiteID
+
+			continue
+		}
+
+		if seen[siteId] {
+			continue
+		}
+
+		seen[siteId] = true
+		idLength := len(strconv.Itoa(siteId)) + 1
+
+		if len(batch) == MaxBulkSiteIds || length+idLength > maxBulkSiteIdsLength {
+			batches = append(batches, batch)
+			batch = []int{}
+			length = 0
+		}
+
+		batch = append(batch, siteId)
+		length += idLength
+	}
+
+	if len(batch) > 0 {
+		batches = append(batches, batch)
+	}
+
+	return batches
+}
+
+// batchSites executes fetch for every batch of siteIds, at most bulkConcurrency at the same time, and merges the results.
+// A failed batch reports its error for every site of the batch, sites missing from a response report ErrSiteNotInResponse.
+// Once ctx is done the remaining batches are not sent and report the error of ctx.
+// The returned error joins the errors of all failed batches and is nil if every site succeeded.
+func batchSites[T any](ctx context.Context, siteIds []int, fetch func(batch []int) (map[int]T, error)) (BulkResult[T], error) {
+	result := BulkResult[T]{Values: map[int]T{}, Errors: map[int]error{}}
+
+	if len(siteIds) == 0 {
+		return result, ErrMissingSiteIDs
+	}
+
+	batches := batchSiteIds(siteIds, result.Errors)
+	semaphore := make(chan struct{}, bulkConcurrency)
+	mutex := sync.Mutex{}
+	wait := sync.WaitGroup{}
+	batchErrors := []error{}
+	cancelled := false
+
+	for i, batch := range batches {
+		select {
+		case semaphore <- struct{}{}:
+		case <-ctx.Done():
+		}
+
+		if err := ctx.Err(); err != nil {
+			mutex.Lock()
+
+			for _, remaining := range batches[i:] {
+				for _, siteId := range remaining {
+					result.Errors[siteId] = err
+				}
+			}
+
+			cancelled = true
+			mutex.Unlock()
+
+			break
+		}
+
+		wait.Add(1)
+
+		go func() {
+			defer wait.Done()
+			defer func() { <-semaphore }()
+
+			values, err := fetch(batch)
+
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			// the error of ctx is reported once for every batch it stopped
+			switch {
+			case err != nil && ctx.Err() != nil && errors.Is(err, ctx.Err()):
+				cancelled = true
+			case err != nil:
+				batchErrors = append(batchErrors, err)
+			}
+
+			for _, siteId := range batch {
+				value, ok := values[siteId]
+
+				switch {
+				case err != nil:
+					result.Errors[siteId] = err
+				case !ok:
+					result.Errors[siteId] = fmt.Errorf("%w: site %d", ErrSiteNotInResponse, siteId)
+				default:
+					result.Values[siteId] = value
+				}
+			}
+		}()
+	}
+
+	wait.Wait()
+
+	if cancelled {
+		batchErrors = append(batchErrors, ctx.Err())
+	}
+
+	if len(result.Errors) > 0 && len(batchErrors) == 0 {
+		batchErrors = append(batchErrors, fmt.Errorf("%d of %d sites failed", len(result.Errors), len(result.Errors)+len(result.Values)))
+	}
+
+	return result, errors.Join(batchErrors...)
+}
+
+// GetSiteDataStartAndEndDatesBatched returns the data period of any number of sites, see batchSites.
+func (client *Client) GetSiteDataStartAndEndDatesBatched(ctx context.Context, params SiteDataStartAndEndDatesBulkParams) (BulkResult[DataPeriod], error) {
+	return batchSites(ctx, params.SiteIds, func(batch []int) (map[int]DataPeriod, error) {
+		response, err := client.GetSiteDataStartAndEndDatesBulk(ctx, SiteDataStartAndEndDatesBulkParams{SiteIds: batch})
+		values := map[int]DataPeriod{}
+
+		for _, period := range response.DataPeriodList.SiteEnergyList {
+			client.localize(period.Id, &period)
+			values[period.Id] = DataPeriod{StartDate: period.StartDate, EndDate: period.EndDate}
+		}
+
+		return values, err
+	})
+}
+
+// GetSiteEnergyBatched returns the energy measurements of any number of sites, see batchSites.
+func (client *Client) GetSiteEnergyBatched(ctx context.Context, params SiteEnergyBulkParams) (BulkResult[MeasuredValues], error) {
+	return batchSites(ctx, params.SiteIds, func(batch []int) (map[int]MeasuredValues, error) {
+		batchParams := params
+		batchParams.SiteIds = batch
+
+		response, err := client.GetSiteEnergyBulk(ctx, batchParams)
+		values := map[int]MeasuredValues{}
+
+		for _, site := range response.SitesEnergy.SiteEnergyList {
+			client.localize(site.SiteId, &site)
+			values[site.SiteId] = site.EnergyValues
+		}
+
+		return values, err
+	})
+}
+
+// GetSiteEnergyTimePeriodBatched returns the energy produced in a time period by any number of sites, see batchSites.
+func (client *Client) GetSiteEnergyTimePeriodBatched(ctx context.Context, params SiteEnergyTimePeriodBulkParams) (BulkResult[SiteEnergyTimePeriod], error) {
+	return batchSites(ctx, params.SiteIds, func(batch []int) (map[int]SiteEnergyTimePeriod, error) {
+		batchParams := params
+		batchParams.SiteIds = batch
+
+		response, err := client.GetSiteEnergyTimePeriodBulk(ctx, batchParams)
+		values := map[int]SiteEnergyTimePeriod{}
+
+		for _, site := range response.TimeFrameEnergyList.TimeFrameEnergyList {
+			client.localize(site.SiteId, &site)
+			values[site.SiteId] = site.TimeFrameEnergy
+		}
+
+		return values, err
+	})
+}
+
+// GetSitePowerBatched returns the power measurements of any number of sites, see batchSites.
+// A bulk request has a single range for all of its sites: the times are converted to the time zone of the sites only if
+// every site of the batch has the same known time zone (see SiteTimeZone), otherwise they are sent as the wall clock of their own location.
+func (client *Client) GetSitePowerBatched(ctx context.Context, params SitePowerBulkParams) (BulkResult[MeasuredValues], error) {
+	return batchSites(ctx, params.SiteIds, func(batch []int) (map[int]MeasuredValues, error) {
+		batchParams := params
+		batchParams.SiteIds = batch
+
+		response, err := client.GetSitePowerBulk(ctx, batchParams)
+		values := map[int]MeasuredValues{}
+
+		for _, site := range response.PowerDateValuesList.SiteEnergyList {
+			client.localize(site.SiteId, &site)
+			values[site.SiteId] = site.PowerDataValueSeries
+		}
+
+		return values, err
+	})
+}
+
+// GetSiteOverviewBatched returns the overview of any number of sites, see batchSites.
+func (client *Client) GetSiteOverviewBatched(ctx context.Context, params SiteOverviewBulkParams) (BulkResult[SiteOverview], error) {
+	return batchSites(ctx, params.SiteIds, func(batch []int) (map[int]SiteOverview, error) {
+		response, err := client.GetSiteOverviewBulk(ctx, SiteOverviewBulkParams{SiteIds: batch})
+		values := map[int]SiteOverview{}
+
+		for _, site := range response.SitesOverviews.SiteEnergyList {
+			client.localize(site.SiteId, &site)
+			values[site.SiteId] = site.SiteOverview
+		}
+
+		return values, err
+	})
+}
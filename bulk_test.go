@@ -0,0 +1,77 @@
+package golaredge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// TestClientGetSiteOverviewBatched checks that several hundred sites are split in batches of at most 100 sites
+// and that sites missing from a response are reported individually.
+func TestClientGetSiteOverviewBatched(t *testing.T) {
+	requests := atomic.Int32{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+
+		ids := strings.Split(strings.Split(r.URL.Path, "/")[2], ",")
+
+		if len(ids) > MaxBulkSiteIds {
+			t.Errorf("batch of %d sites", len(ids))
+		}
+
+		entries := []string{}
+
+		for _, id := range ids {
+			// site 13 is not accessible
+			if id != "13" {
+				entries = append(entries, fmt.Sprintf(`{"siteId":%s,"siteOverview":{"currentPower":{"power":%s}}}`, id, id))
+			}
+		}
+
+		fmt.Fprintf(w, `{"sitesOverviews":{"count":%d,"siteEnergyList":[%s]}}`, len(entries), strings.Join(entries, ","))
+	})
+
+	siteIds := []int{-1}
+
+	for i := range 250 {
+		siteIds = append(siteIds, i)
+	}
+
+	result, err := client.GetSiteOverviewBatched(t.Context(), SiteOverviewBulkParams{SiteIds: append(siteIds, 5)})
+
+	if err == nil {
+		t.Error("GetSiteOverviewBatched() error = nil, want error for the failed sites")
+	}
+
+	if requests.Load() != 3 {
+		t.Errorf("requests = %d, want 3", requests.Load())
+	}
+
+	if len(result.Values) != 249 || result.Values[200].CurrentPower.Power != 200 {
+		t.Errorf("GetSiteOverviewBatched() = %d values", len(result.Values))
+	}
+
+	if !errors.Is(result.Errors[13], ErrSiteNotInResponse) || !errors.Is(result.Errors[-1], ErrInvalidSiteID) || len(result.Errors) != 2 {
+		t.Errorf("GetSiteOverviewBatched() errors = %v", result.Errors)
+	}
+}
+
+// TestClientGetSiteOverviewBatchedCancelled checks that no batch is sent once the context is cancelled and that every site reports it.
+func TestClientGetSiteOverviewBatchedCancelled(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s", r.URL.Path)
+	})
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	result, err := client.GetSiteOverviewBatched(ctx, SiteOverviewBulkParams{SiteIds: []int{1, 2, 3}})
+
+	if !errors.Is(err, context.Canceled) || len(result.Errors) != 3 || !errors.Is(result.Errors[2], context.Canceled) {
+		t.Errorf("GetSiteOverviewBatched() = %v, %v, want context.Canceled for every site", result.Errors, err)
+	}
+}
@@ -0,0 +1,273 @@
+package golaredge
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Cache stores API responses keyed on the canonical form of the request (see Request.Canonical), prefixed with a hash of the api key
+// of the client so that clients of different accounts can share a cache. A ttl of 0 means the value never expires.
+// Implementations must be safe for concurrent use.
+type Cache interface {
+	Get(key string) ([]byte, bool)
+	Set(key string, value []byte, ttl time.Duration)
+}
+
+// DefaultCacheTTLs are the time to live of the responses of every endpoint, keyed on the endpoint (see Endpoint).
+// Endpoints that are not listed are never cached. Time series whose end date lies before today at the site never change and are cached forever.
+var DefaultCacheTTLs = map[string]time.Duration{
+	"sites/list":                                  6 * time.Hour,
+	"site/{siteId}/details":                       6 * time.Hour,
+	"site/{siteId}/dataPeriod":                    time.Hour,
+	"sites/{siteIds}/dataPeriod":                  time.Hour,
+	"site/{siteId}/energy":                        15 * time.Minute,
+	"sites/{siteIds}/energy":                      15 * time.Minute,
+	"site/{siteId}/timeFrameEnergy":               15 * time.Minute,
+	"sites/{siteIds}/timeFrameEnergy":             15 * time.Minute,
+	"site/{siteId}/power":                         15 * time.Minute,
+	"sites/{siteIds}/power":                       15 * time.Minute,
+	"site/{siteId}/overview":                      5 * time.Minute,
+	"sites/{siteIds}/overview":                    5 * time.Minute,
+	"site/{siteId}/powerDetails":                  15 * time.Minute,
+	"site/{siteId}/energyDetails":                 15 * time.Minute,
+	"site/{siteId}/currentPowerFlow":              time.Minute,
+	"site/{siteId}/storageData":                   15 * time.Minute,
+	"site/{siteId}/siteImage":                     24 * time.Hour,
+	"site/{siteId}/siteImage/{name}":              24 * time.Hour,
+	"site/{siteId}/envBenefits":                   time.Hour,
+	"site/{siteId}/installerImage":                24 * time.Hour,
+	"site/{siteId}/installerImage/{name}":         24 * time.Hour,
+	"equipment/{siteId}/list":                     6 * time.Hour,
+	"site/{siteId}/inventory":                     6 * time.Hour,
+	"equipment/{siteId}/{serialNumber}/data":      15 * time.Minute,
+	"equipment/{siteId}/{serialNumber}/changeLog": 6 * time.Hour,
+	"accounts/list":                               6 * time.Hour,
+	"site/{siteId}/meters":                        15 * time.Minute,
+	"equipment/{siteId}/sensors":                  6 * time.Hour,
+	"site/{siteId}/sensors":                       15 * time.Minute,
+	"version/current":                             24 * time.Hour,
+	"version/supported":                           24 * time.Hour,
+}
+
+// cacheTTL returns the time to live for the response of request and whether it can be cached at all, now being the current time
+// at the site of the request (see Client.siteNow).
+func cacheTTL(ttls map[string]time.Duration, request Request, now time.Time) (time.Duration, bool) {
+	ttl, ok := ttls[string(request.Endpoint)]
+
+	if !ok {
+		return 0, false
+	}
+
+	end := request.Query.Get("endDate")
+
+	if end == "" {
+		end = request.Query.Get("endTime")
+	}
+
+	// data of completed days does not change anymore
+	if len(end) >= len(dateLayout) && end[:len(dateLayout)] < now.Format(dateLayout) {
+		return 0, true
+	}
+
+	return ttl, true
+}
+
+// WithCache caches the responses of the client in cache, using the time to live of ttls (DefaultCacheTTLs if nil).
+func WithCache(cache Cache, ttls map[string]time.Duration) Option {
+	if ttls == nil {
+		ttls = DefaultCacheTTLs
+	}
+
+	return func(client *Client) {
+		client.cache = cache
+		client.cacheTTLs = ttls
+	}
+}
+
+// cacheKey returns the key of the response of request in the cache, its canonical form prefixed with a hash of the api key.
+func (client *Client) cacheKey(request Request) string {
+	hash := sha256.Sum256([]byte(client.apiKey))
+
+	return hex.EncodeToString(hash[:8]) + "/" + request.Canonical()
+}
+
+// cached reports whether the response of request is in the cache of the client.
+func (client *Client) cached(request Request) bool {
+	if client.cache == nil {
+		return false
+	}
+
+	if _, cacheable := cacheTTL(client.cacheTTLs, request, client.siteNow(request)); !cacheable {
+		return false
+	}
+
+	_, ok := client.cache.Get(client.cacheKey(request))
+
+	return ok
+}
+
+// store caches bytes as the response of request, if the client has a cache and the endpoint is cacheable.
+func (client *Client) store(request Request, bytes []byte) {
+	ttl, cacheable := cacheTTL(client.cacheTTLs, request, client.siteNow(request))
+
+	if cacheable && client.cache != nil {
+		client.cache.Set(client.cacheKey(request), bytes, ttl)
+	}
+}
+
+type memoryCacheEntry struct {
+	key     string
+	value   []byte
+	expires time.Time
+}
+
+// MemoryCache is an in memory least recently used cache holding at most capacity responses.
+type MemoryCache struct {
+	capacity int
+	now      func() time.Time
+
+	mutex   sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List
+}
+
+// NewMemoryCache returns an empty cache holding at most capacity responses.
+func NewMemoryCache(capacity int) *MemoryCache {
+	return &MemoryCache{
+		capacity: capacity,
+		now:      time.Now,
+		entries:  map[string]*list.Element{},
+		order:    list.New(),
+	}
+}
+
+func (cache *MemoryCache) Get(key string) ([]byte, bool) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	element, ok := cache.entries[key]
+
+	if !ok {
+		return nil, false
+	}
+
+	entry := element.Value.(*memoryCacheEntry)
+
+	if !entry.expires.IsZero() && cache.now().After(entry.expires) {
+		cache.order.Remove(element)
+		delete(cache.entries, key)
+
+		return nil, false
+	}
+
+	cache.order.MoveToFront(element)
+
+	// the caller may modify the value, e.g. an image
+	return bytes.Clone(entry.value), true
+}
+
+func (cache *MemoryCache) Set(key string, value []byte, ttl time.Duration) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	entry := &memoryCacheEntry{key: key, value: bytes.Clone(value)}
+
+	if ttl > 0 {
+		entry.expires = cache.now().Add(ttl)
+	}
+
+	if element, ok := cache.entries[key]; ok {
+		element.Value = entry
+		cache.order.MoveToFront(element)
+
+		return
+	}
+
+	cache.entries[key] = cache.order.PushFront(entry)
+
+	for cache.capacity > 0 && cache.order.Len() > cache.capacity {
+		oldest := cache.order.Back()
+		cache.order.Remove(oldest)
+		delete(cache.entries, oldest.Value.(*memoryCacheEntry).key)
+	}
+}
+
+// DiskCache stores every response in its own file in a directory, so that the cache survives restarts.
+// Each file starts with the expiry time (unix nanoseconds, 0 if it never expires) followed by the response.
+type DiskCache struct {
+	dir string
+	now func() time.Time
+}
+
+// NewDiskCache returns a cache storing its files in dir, which is created if it does not exist.
+func NewDiskCache(dir string) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+
+	return &DiskCache{dir: dir, now: time.Now}, nil
+}
+
+func (cache *DiskCache) path(key string) string {
+	hash := sha256.Sum256([]byte(key))
+
+	return filepath.Join(cache.dir, hex.EncodeToString(hash[:]))
+}
+
+func (cache *DiskCache) Get(key string) ([]byte, bool) {
+	path := cache.path(key)
+	bytes, err := os.ReadFile(path)
+
+	if err != nil || len(bytes) < 8 {
+		return nil, false
+	}
+
+	expires := int64(binary.BigEndian.Uint64(bytes[:8]))
+
+	if expires != 0 && cache.now().UnixNano() > expires {
+		os.Remove(path)
+
+		return nil, false
+	}
+
+	return bytes[8:], true
+}
+
+func (cache *DiskCache) Set(key string, value []byte, ttl time.Duration) {
+	var expires int64
+
+	if ttl > 0 {
+		expires = cache.now().Add(ttl).UnixNano()
+	}
+
+	bytes := binary.BigEndian.AppendUint64(make([]byte, 0, len(value)+8), uint64(expires))
+	bytes = append(bytes, value...)
+
+	// write to a temporary file first so that concurrent readers never see a partial response
+	temp, err := os.CreateTemp(cache.dir, "tmp-*")
+
+	if err != nil {
+		return
+	}
+
+	_, err = temp.Write(bytes)
+	err = errors.Join(err, temp.Close())
+
+	if err != nil {
+		os.Remove(temp.Name())
+
+		return
+	}
+
+	if os.Rename(temp.Name(), cache.path(key)) != nil {
+		os.Remove(temp.Name())
+	}
+}
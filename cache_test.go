@@ -0,0 +1,178 @@
+package golaredge
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestMemoryCacheEviction checks that the least recently used entry is evicted and expired entries are dropped.
+func TestMemoryCacheEviction(t *testing.T) {
+	now := time.Now()
+	cache := NewMemoryCache(2)
+	cache.now = func() time.Time { return now }
+
+	cache.Set("a", []byte("a"), 0)
+	cache.Set("b", []byte("b"), time.Minute)
+	cache.Get("a")
+	cache.Set("c", []byte("c"), 0)
+
+	if _, ok := cache.Get("b"); ok {
+		t.Error("Get(b) found, want evicted")
+	}
+
+	if value, ok := cache.Get("a"); !ok || string(value) != "a" {
+		t.Errorf("Get(a) = %q, %v, want a", value, ok)
+	}
+
+	cache.Set("b", []byte("b"), time.Minute)
+	now = now.Add(2 * time.Minute)
+
+	if _, ok := cache.Get("b"); ok {
+		t.Error("Get(b) found, want expired")
+	}
+}
+
+// TestDiskCache checks that values survive a new cache on the same directory.
+func TestDiskCache(t *testing.T) {
+	dir := t.TempDir()
+	cache, err := NewDiskCache(dir)
+
+	if err != nil {
+		t.Fatalf("NewDiskCache() error = %v", err)
+	}
+
+	cache.Set("site/1/details", []byte(`{"details":{}}`), time.Hour)
+
+	reopened, _ := NewDiskCache(dir)
+
+	if value, ok := reopened.Get("site/1/details"); !ok || string(value) != `{"details":{}}` {
+		t.Errorf("Get() = %q, %v", value, ok)
+	}
+
+	reopened.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+
+	if _, ok := reopened.Get("site/1/details"); ok {
+		t.Error("Get() found, want expired")
+	}
+}
+
+// TestClientCache checks that a cached response is served without a request, that the api key itself is not part of the key
+// and that clients of different api keys sharing a cache do not see the responses of each other.
+func TestClientCache(t *testing.T) {
+	requests := 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"overview":{"currentPower":{"power":1}}}`))
+	})
+
+	cache := NewMemoryCache(10)
+	WithCache(cache, nil)(client)
+
+	for range 2 {
+		if _, err := client.GetSiteOverview(t.Context(), SiteOverviewParams{SiteId: 1}); err != nil {
+			t.Fatalf("GetSiteOverview() error = %v", err)
+		}
+	}
+
+	if requests != 1 {
+		t.Errorf("requests = %d, want 1", requests)
+	}
+
+	key := client.cacheKey(Request{Endpoint: EndpointSiteOverview, PathParams: map[string]string{"siteId": "1"}})
+
+	if _, ok := cache.Get(key); !ok || !strings.HasSuffix(key, "/site/1/overview") || strings.Contains(key, "test-key") {
+		t.Errorf("cache.Get(%q) found = %v", key, ok)
+	}
+
+	other := NewClient("other-key", WithBaseURL(client.baseUrl.String()), WithCache(cache, nil))
+
+	if _, err := other.GetSiteOverview(t.Context(), SiteOverviewParams{SiteId: 1}); err != nil || requests != 2 {
+		t.Errorf("got %d requests, %v, want the other api key to send its own request", requests, err)
+	}
+}
+
+// TestMemoryCacheCopies checks that modifying a value stored in or returned by the cache does not modify the cached value.
+func TestMemoryCacheCopies(t *testing.T) {
+	cache := NewMemoryCache(1)
+	value := []byte("image")
+	cache.Set("a", value, 0)
+	value[0] = 'I'
+
+	got, _ := cache.Get("a")
+	got[1] = 'M'
+
+	if got, _ := cache.Get("a"); string(got) != "image" {
+		t.Errorf("Get() = %q, want %q", got, "image")
+	}
+}
+
+// TestCacheTTL checks the per endpoint time to live and that completed days are cached forever.
+func TestCacheTTL(t *testing.T) {
+	now := time.Date(2024, 6, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		url       string
+		ttl       time.Duration
+		cacheable bool
+	}{
+		{"https://monitoringapi.solaredge.com/site/1/currentPowerFlow?api_key=x", time.Minute, true},
+		{"https://monitoringapi.solaredge.com/site/1/energy?endDate=2024-06-01&startDate=2024-05-01", 0, true},
+		{"https://monitoringapi.solaredge.com/site/1/energy?endDate=2024-06-10&startDate=2024-06-01", 15 * time.Minute, true},
+		{"https://monitoringapi.solaredge.com/equipment/1/SN1/data?endTime=2024-06-10+10:00:00", 15 * time.Minute, true},
+		{"https://monitoringapi.solaredge.com/unknown/endpoint", 0, false},
+	}
+
+	for _, test := range tests {
+		request, err := ParseRequest(test.url)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ttl, cacheable := cacheTTL(DefaultCacheTTLs, request, now)
+
+		if ttl != test.ttl || cacheable != test.cacheable {
+			t.Errorf("cacheTTL(%s) = %v, %v, want %v, %v", test.url, ttl, cacheable, test.ttl, test.cacheable)
+		}
+	}
+}
+
+// TestCacheTTLTimeZone checks that a time series is only cached forever once its end date lies before today at the site,
+// and before yesterday in UTC for a site with an unknown time zone.
+func TestCacheTTLTimeZone(t *testing.T) {
+	losAngeles, err := time.LoadLocation("America/Los_Angeles")
+
+	if err != nil {
+		t.Skip(err)
+	}
+
+	// 2024-01-01 20:00 in Los Angeles
+	now := time.Date(2024, 1, 2, 4, 0, 0, 0, time.UTC)
+	client := NewClient("test-key", WithClock(func() time.Time { return now }))
+	client.SetSiteTimeZone(1, losAngeles)
+
+	tests := []struct {
+		url string
+		ttl time.Duration
+	}{
+		{"https://monitoringapi.solaredge.com/site/1/energy?endDate=2024-01-01&startDate=2023-12-01", 15 * time.Minute},
+		{"https://monitoringapi.solaredge.com/site/1/energy?endDate=2023-12-31&startDate=2023-12-01", 0},
+		{"https://monitoringapi.solaredge.com/site/2/energy?endDate=2024-01-01&startDate=2023-12-01", 15 * time.Minute},
+		{"https://monitoringapi.solaredge.com/site/2/energy?endDate=2023-12-31&startDate=2023-12-01", 0},
+		{"https://monitoringapi.solaredge.com/sites/1,2/energy?endDate=2024-01-01&startDate=2023-12-01", 15 * time.Minute},
+	}
+
+	for _, test := range tests {
+		request, err := ParseRequest(test.url)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if ttl, _ := cacheTTL(DefaultCacheTTLs, request, client.siteNow(request)); ttl != test.ttl {
+			t.Errorf("cacheTTL(%s) = %v, want %v", test.url, ttl, test.ttl)
+		}
+	}
+}
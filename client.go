@@ -0,0 +1,363 @@
+package golaredge
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// Client executes the requests built by the Get*Request functions against the SolarEdge Monitoring API
+// and decodes the responses.
+type Client struct {
+	apiKey     string
+	httpClient *http.Client
+	baseUrl    *url.URL
+	userAgent  string
+	now        func() time.Time
+	limiter    *RateLimiter
+	retry      *RetryPolicy
+	coalescer  *coalescer
+	merger     *merger
+	logger     *slog.Logger
+	cache      Cache
+	cacheTTLs  map[string]time.Duration
+
+	middlewares []Middleware
+
+	validationMode     ValidationMode
+	validationWarnings func(warnings *ValidationError)
+
+	timeZoneMutex   sync.RWMutex
+	timeZones       map[int]*time.Location
+	defaultTimeZone *time.Location
+
+	// err is the error of an invalid option, returned by every request.
+	err error
+}
+
+// Option configures a Client, see NewClient.
+type Option func(client *Client)
+
+// NewClient returns a client authenticating with apiKey against https://monitoringapi.solaredge.com/.
+// By default the client enforces the limits documented by SolarEdge (300 requests per day, 3 concurrent requests)
+// and waits until a request is allowed, use WithRateLimiter to change this.
+func NewClient(apiKey string, options ...Option) *Client {
+	baseUrl, _ := url.Parse(baseUri)
+
+	client := &Client{
+		apiKey:     apiKey,
+		httpClient: http.DefaultClient,
+		baseUrl:    baseUrl,
+		now:        time.Now,
+		limiter:    NewRateLimiter(RateLimitConfig{}),
+		timeZones:  map[int]*time.Location{},
+	}
+
+	for _, option := range options {
+		option(client)
+	}
+
+	return client
+}
+
+// WithRateLimiter replaces the default rate limiter. Clients using the same api key should share a single limiter.
+// A nil limiter disables rate limiting.
+func WithRateLimiter(limiter *RateLimiter) Option {
+	return func(client *Client) {
+		client.limiter = limiter
+	}
+}
+
+// WithHTTPClient sends the requests with httpClient instead of http.DefaultClient, e.g. to use a recorder.Recorder.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(client *Client) {
+		client.httpClient = httpClient
+	}
+}
+
+// WithTransport sends the requests through transport, e.g. to add a proxy or tracing to the http client of the client.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(client *Client) {
+		httpClient := *client.httpClient
+		httpClient.Transport = transport
+		client.httpClient = &httpClient
+	}
+}
+
+// WithUserAgent sends userAgent as the User-Agent header of every request instead of the default of net/http.
+func WithUserAgent(userAgent string) Option {
+	return func(client *Client) {
+		client.userAgent = userAgent
+	}
+}
+
+// WithClock replaces time.Now as the current time of the client, used to validate the date ranges of the requests,
+// to decide how long responses are cached and to interpret Retry-After dates. The rate limiter and the caches keep their own clock.
+func WithClock(now func() time.Time) Option {
+	return func(client *Client) {
+		client.now = now
+	}
+}
+
+// WithBaseURL sends the requests to baseUrl instead of https://monitoringapi.solaredge.com/, e.g. a proxy or a fakeapi.Server.
+// Every request fails if baseUrl is not an absolute url.
+func WithBaseURL(baseUrl string) Option {
+	return func(client *Client) {
+		parsed, err := url.Parse(baseUrl)
+
+		if err == nil && (parsed.Scheme == "" || parsed.Host == "") {
+			err = fmt.Errorf("base url %q is not absolute", baseUrl)
+		}
+
+		if err != nil {
+			client.err = err
+
+			return
+		}
+
+		client.baseUrl = parsed
+	}
+}
+
+// RemainingRequests returns the number of requests the client can still execute today, or -1 when rate limiting is disabled.
+func (client *Client) RemainingRequests() int {
+	if client.limiter == nil {
+		return -1
+	}
+
+	return client.limiter.Remaining(client.apiKey)
+}
+
+// RemainingSiteRequests returns the number of requests that can still be executed today for siteId, or -1 when rate limiting is disabled.
+func (client *Client) RemainingSiteRequests(siteId int) int {
+	if client.limiter == nil {
+		return -1
+	}
+
+	return client.limiter.RemainingForSite(siteId)
+}
+
+// Site Data API
+
+// GetSiteList also records the time zone of every returned site, see SiteTimeZone.
+func (client *Client) GetSiteList(ctx context.Context, params SiteListParams) (SiteListResponse, error) {
+	request, err := clientRequest(client, siteListRequest, params)
+	response, err := fetch[SiteListResponse](ctx, client, request, params, err)
+
+	client.rememberTimeZones(response.Sites.Site)
+
+	for i := range response.Sites.Site {
+		client.localize(response.Sites.Site[i].Id, &response.Sites.Site[i])
+	}
+
+	return response, err
+}
+
+// GetSite also records the time zone of the site, see SiteTimeZone.
+func (client *Client) GetSite(ctx context.Context, params SiteParams) (SiteDetailsResponse, error) {
+	request, err := clientRequest(client, siteRequest, params)
+	response, err := fetch[SiteDetailsResponse](ctx, client, request, params, err)
+
+	client.rememberTimeZones([]Site{response.Details})
+	client.localize(params.SiteId, &response)
+
+	return response, err
+}
+
+func (client *Client) GetSiteDataStartAndEndDates(ctx context.Context, params SiteDataStartAndEndDatesParams) (SiteDataPeriodResponse, error) {
+	request, err := clientRequest(client, siteDataStartAndEndDatesRequest, params)
+
+	return fetchSite[SiteDataPeriodResponse](ctx, client, params.SiteId, request, params, err)
+}
+
+func (client *Client) GetSiteDataStartAndEndDatesBulk(ctx context.Context, params SiteDataStartAndEndDatesBulkParams) (SiteDataPeriodBulkResponse, error) {
+	request, err := clientRequest(client, siteDataStartAndEndDatesBulkRequest, params)
+
+	return fetch[SiteDataPeriodBulkResponse](ctx, client, request, params, err)
+}
+
+func (client *Client) GetSiteEnergy(ctx context.Context, params SiteEnergyParams) (SiteEnergyResponse, error) {
+	params.StartDate, params.EndDate = client.siteTimes(params.SiteId, params.StartDate, params.EndDate)
+
+	request, err := clientRequest(client, siteEnergyRequest, params)
+
+	return fetchSite[SiteEnergyResponse](ctx, client, params.SiteId, request, params, err)
+}
+
+func (client *Client) GetSiteEnergyBulk(ctx context.Context, params SiteEnergyBulkParams) (SiteEnergyBulkResponse, error) {
+	params.StartDate, params.EndDate = client.sitesTimes(params.SiteIds, params.StartDate, params.EndDate)
+
+	request, err := clientRequest(client, siteEnergyBulkRequest, params)
+
+	return fetch[SiteEnergyBulkResponse](ctx, client, request, params, err)
+}
+
+func (client *Client) GetSiteEnergyTimePeriod(ctx context.Context, params SiteEnergyTimePeriodParams) (SiteEnergyTimePeriodResponse, error) {
+	params.StartDate, params.EndDate = client.siteTimes(params.SiteId, params.StartDate, params.EndDate)
+
+	request, err := clientRequest(client, siteEnergyTimePeriodRequest, params)
+
+	return fetchSite[SiteEnergyTimePeriodResponse](ctx, client, params.SiteId, request, params, err)
+}
+
+func (client *Client) GetSiteEnergyTimePeriodBulk(ctx context.Context, params SiteEnergyTimePeriodBulkParams) (SiteEnergyTimePeriodBulkResponse, error) {
+	params.StartDate, params.EndDate = client.sitesTimes(params.SiteIds, params.StartDate, params.EndDate)
+
+	request, err := clientRequest(client, siteEnergyTimePeriodBulkRequest, params)
+
+	return fetch[SiteEnergyTimePeriodBulkResponse](ctx, client, request, params, err)
+}
+
+func (client *Client) GetSitePower(ctx context.Context, params SitePowerParams) (SitePowerResponse, error) {
+	params.StartTime, params.EndTime = client.siteTimes(params.SiteId, params.StartTime, params.EndTime)
+
+	request, err := clientRequest(client, sitePowerRequest, params)
+
+	return fetchSite[SitePowerResponse](ctx, client, params.SiteId, request, params, err)
+}
+
+func (client *Client) GetSitePowerBulk(ctx context.Context, params SitePowerBulkParams) (SitePowerBulkResponse, error) {
+	params.StartTime, params.EndTime = client.sitesTimes(params.SiteIds, params.StartTime, params.EndTime)
+
+	request, err := clientRequest(client, sitePowerBulkRequest, params)
+
+	return fetch[SitePowerBulkResponse](ctx, client, request, params, err)
+}
+
+func (client *Client) GetSiteOverview(ctx context.Context, params SiteOverviewParams) (SiteOverviewResponse, error) {
+	request, err := clientRequest(client, siteOverviewRequest, params)
+
+	return fetchSite[SiteOverviewResponse](ctx, client, params.SiteId, request, params, err)
+}
+
+func (client *Client) GetSiteOverviewBulk(ctx context.Context, params SiteOverviewBulkParams) (SiteOverviewBulkResponse, error) {
+	request, err := clientRequest(client, siteOverviewBulkRequest, params)
+
+	return fetch[SiteOverviewBulkResponse](ctx, client, request, params, err)
+}
+
+func (client *Client) GetSitePowerDetailed(ctx context.Context, params SitePowerDetailedParams) (SitePowerDetailedResponse, error) {
+	params.StartTime, params.EndTime = client.siteTimes(params.SiteId, params.StartTime, params.EndTime)
+
+	request, err := clientRequest(client, sitePowerDetailedRequest, params)
+
+	return fetchSite[SitePowerDetailedResponse](ctx, client, params.SiteId, request, params, err)
+}
+
+func (client *Client) GetSiteEnergyDetailed(ctx context.Context, params SiteEnergyDetailedParams) (SiteEnergyDetailedResponse, error) {
+	params.StartTime, params.EndTime = client.siteTimes(params.SiteId, params.StartTime, params.EndTime)
+
+	request, err := clientRequest(client, siteEnergyDetailedRequest, params)
+
+	return fetchSite[SiteEnergyDetailedResponse](ctx, client, params.SiteId, request, params, err)
+}
+
+func (client *Client) GetSitePowerFlow(ctx context.Context, params SitePowerFlowParams) (SitePowerFlowResponse, error) {
+	request, err := clientRequest(client, sitePowerFlowRequest, params)
+
+	return fetchSite[SitePowerFlowResponse](ctx, client, params.SiteId, request, params, err)
+}
+
+func (client *Client) GetStorageInformation(ctx context.Context, params StorageInformationParams) (StorageInformationResponse, error) {
+	params.StartTime, params.EndTime = client.siteTimes(params.SiteId, params.StartTime, params.EndTime)
+
+	request, err := clientRequest(client, storageInformationRequest, params)
+
+	return fetchSite[StorageInformationResponse](ctx, client, params.SiteId, request, params, err)
+}
+
+// GetSiteImage returns the raw image bytes as sent by the API.
+func (client *Client) GetSiteImage(ctx context.Context, params SiteImageParams) ([]byte, error) {
+	request, err := clientRequest(client, siteImageRequest, params)
+
+	return fetchBytes(ctx, client, request, params, err)
+}
+
+func (client *Client) GetSiteEnvironmentalBenefits(ctx context.Context, params SiteEnvironmentalBenefitsParams) (SiteEnvironmentalBenefitsResponse, error) {
+	request, err := clientRequest(client, siteEnvironmentalBenefitsRequest, params)
+
+	return fetchSite[SiteEnvironmentalBenefitsResponse](ctx, client, params.SiteId, request, params, err)
+}
+
+// GetInstallerImage returns the raw image bytes as sent by the API.
+func (client *Client) GetInstallerImage(ctx context.Context, params SiteImageParams) ([]byte, error) {
+	request, err := clientRequest(client, installerImageRequest, params)
+
+	return fetchBytes(ctx, client, request, params, err)
+}
+
+// Site Equipment API
+
+func (client *Client) GetComponentsList(ctx context.Context, params ComponentsListParams) (ComponentsListResponse, error) {
+	request, err := clientRequest(client, componentsListRequest, params)
+
+	return fetchSite[ComponentsListResponse](ctx, client, params.SiteId, request, params, err)
+}
+
+func (client *Client) GetInventory(ctx context.Context, params InventoryParams) (InventoryResponse, error) {
+	request, err := clientRequest(client, inventoryRequest, params)
+
+	return fetchSite[InventoryResponse](ctx, client, params.SiteId, request, params, err)
+}
+
+func (client *Client) GetInverterTechnicalData(ctx context.Context, params InverterTechnicalDataParams) (InverterTechnicalDataResponse, error) {
+	params.StartTime, params.EndTime = client.siteTimes(params.SiteId, params.StartTime, params.EndTime)
+
+	request, err := clientRequest(client, inverterTechnicalDataRequest, params)
+
+	return fetchSite[InverterTechnicalDataResponse](ctx, client, params.SiteId, request, params, err)
+}
+
+func (client *Client) GetEquipmentChangeLog(ctx context.Context, params EquipmentChangeLogParams) (EquipmentChangeLogResponse, error) {
+	request, err := clientRequest(client, equipmentChangeLogRequest, params)
+
+	return fetchSite[EquipmentChangeLogResponse](ctx, client, params.SiteId, request, params, err)
+}
+
+// Account List API
+
+func (client *Client) GetAccountList(ctx context.Context, params AccountListParams) (AccountListResponse, error) {
+	request, err := clientRequest(client, accountListRequest, params)
+
+	return fetch[AccountListResponse](ctx, client, request, params, err)
+}
+
+// Meters API
+
+func (client *Client) GetMetersData(ctx context.Context, params MetersDataParams) (MetersDataResponse, error) {
+	params.StartTime, params.EndTime = client.siteTimes(params.SiteId, params.StartTime, params.EndTime)
+
+	request, err := clientRequest(client, metersDataRequest, params)
+
+	return fetchSite[MetersDataResponse](ctx, client, params.SiteId, request, params, err)
+}
+
+// Sensors API
+
+func (client *Client) GetSensorsList(ctx context.Context, params SensorsListParams) (SensorsListResponse, error) {
+	request, err := clientRequest(client, sensorsListRequest, params)
+
+	return fetchSite[SensorsListResponse](ctx, client, params.SiteId, request, params, err)
+}
+
+func (client *Client) GetSensorData(ctx context.Context, params SensorDataParams) (SensorDataResponse, error) {
+	params.StartDate, params.EndDate = client.siteTimes(params.SiteId, params.StartDate, params.EndDate)
+
+	request, err := clientRequest(client, sensorDataRequest, params)
+
+	return fetchSite[SensorDataResponse](ctx, client, params.SiteId, request, params, err)
+}
+
+// API Versions
+
+func (client *Client) GetCurrentVersion(ctx context.Context) (CurrentVersionResponse, error) {
+	return fetch[CurrentVersionResponse](ctx, client, currentVersionRequest, nil, nil)
+}
+
+func (client *Client) GetSupportedVersion(ctx context.Context) (SupportedVersionResponse, error) {
+	return fetch[SupportedVersionResponse](ctx, client, supportedVersionRequest, nil, nil)
+}
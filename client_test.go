@@ -0,0 +1,172 @@
+package golaredge
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client := NewClient("test-key")
+	client.baseUrl, _ = url.Parse(server.URL)
+
+	return client
+}
+
+// TestClientGetSitePowerFlow checks that the request is sent to the client base url and the response is decoded.
+func TestClientGetSitePowerFlow(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/site/42/currentPowerFlow" || r.URL.Query().Get("api_key") != "test-key" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+
+		w.Write([]byte(`{"siteCurrentPowerFlow":{"updateRefreshRate":3,"unit":"kW","PV":{"status":"Active","currentPower":2.5}}}`))
+	})
+
+	response, err := client.GetSitePowerFlow(t.Context(), SitePowerFlowParams{SiteId: 42})
+
+	if err != nil {
+		t.Fatalf("GetSitePowerFlow() error = %v", err)
+	}
+
+	if response.SiteCurrentPowerFlow.PV == nil || response.SiteCurrentPowerFlow.PV.CurrentPower != 2.5 {
+		t.Errorf("GetSitePowerFlow() = %+v, want PV power 2.5", response)
+	}
+}
+
+// TestClientStatusError checks that a non 200 response is returned as an error.
+func TestClientStatusError(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+
+	if _, err := client.GetSiteOverview(t.Context(), SiteOverviewParams{SiteId: 1}); err == nil {
+		t.Error("GetSiteOverview() error = nil, want error")
+	}
+}
+
+// TestClientAPIError checks that the status code is classified and usable with errors.Is and errors.As.
+func TestClientAPIError(t *testing.T) {
+	tests := []struct {
+		status int
+		body   string
+		want   error
+	}{
+		{http.StatusForbidden, `{"String":"Invalid token"}`, ErrForbidden},
+		{http.StatusTooManyRequests, "Too many requests", ErrQuotaExceeded},
+		{http.StatusTooManyRequests, "Too many concurrent requests", ErrTooManyConcurrent},
+		{http.StatusBadGateway, "", ErrServerError},
+	}
+
+	for _, test := range tests {
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(test.status)
+			w.Write([]byte(test.body))
+		})
+
+		_, err := client.GetSiteOverview(t.Context(), SiteOverviewParams{SiteId: 1})
+
+		if !errors.Is(err, test.want) {
+			t.Errorf("status %d %q: error = %v, want %v", test.status, test.body, err, test.want)
+		}
+
+		var apiError *APIError
+
+		if !errors.As(err, &apiError) || apiError.StatusCode != test.status || apiError.Endpoint != "site/1/overview" {
+			t.Errorf("status %d: error = %#v, want *APIError for site/1/overview", test.status, err)
+		}
+	}
+}
+
+// TestValidationErrors checks that the request builders return the sentinel errors.
+func TestValidationErrors(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	if _, err := GetSiteRequest(SiteParams{SiteId: -1}); !errors.Is(err, ErrInvalidSiteID) {
+		t.Errorf("GetSiteRequest() error = %v, want ErrInvalidSiteID", err)
+	}
+
+	if _, err := GetSitePowerRequest(SitePowerParams{SiteId: 1, StartTime: start}); !errors.Is(err, ErrMissingDates) {
+		t.Errorf("GetSitePowerRequest() error = %v, want ErrMissingDates", err)
+	}
+
+	if _, err := GetSiteEnergyBulkRequest(SiteEnergyBulkParams{}); !errors.Is(err, ErrMissingSiteIDs) {
+		t.Errorf("GetSiteEnergyBulkRequest() error = %v, want ErrMissingSiteIDs", err)
+	}
+}
+
+// TestWithBaseURL checks that the requests are sent to the base url and that an invalid base url fails every request.
+func TestWithBaseURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/proxy/version/current" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+
+		w.Write([]byte(`{"version":{"release":"1.0.0"}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	if _, err := NewClient("key", WithBaseURL(server.URL+"/proxy/")).GetCurrentVersion(t.Context()); err != nil {
+		t.Errorf("GetCurrentVersion() error = %v", err)
+	}
+
+	if _, err := NewClient("key", WithBaseURL("localhost")).GetCurrentVersion(t.Context()); err == nil {
+		t.Error("GetCurrentVersion() error = nil, want error for a relative base url")
+	}
+}
+
+// TestClientDeadline checks that the deadline of the context aborts a request the API does not answer.
+func TestClientDeadline(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+
+	ctx, cancel := context.WithTimeout(t.Context(), 20*time.Millisecond)
+	defer cancel()
+
+	if _, err := client.GetSiteOverview(ctx, SiteOverviewParams{SiteId: 1}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("GetSiteOverview() error = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+type roundTripperFunc func(request *http.Request) (*http.Response, error)
+
+func (roundTrip roundTripperFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return roundTrip(request)
+}
+
+// TestWithTransport checks that the requests go through the transport with the user agent of the client.
+func TestWithTransport(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if userAgent := r.Header.Get("User-Agent"); userAgent != "fleet-poller/1.0" {
+			t.Errorf("User-Agent = %q", userAgent)
+		}
+
+		w.Write([]byte(`{"version":{"release":"1.0.0"}}`))
+	})
+	requests := 0
+
+	WithTransport(roundTripperFunc(func(request *http.Request) (*http.Response, error) {
+		requests++
+
+		return http.DefaultTransport.RoundTrip(request)
+	}))(client)
+	WithUserAgent("fleet-poller/1.0")(client)
+
+	if _, err := client.GetCurrentVersion(t.Context()); err != nil || requests != 1 {
+		t.Errorf("got %d requests, %v", requests, err)
+	}
+
+	if http.DefaultClient.Transport != nil {
+		t.Error("WithTransport changed http.DefaultClient")
+	}
+}
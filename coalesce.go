@@ -0,0 +1,114 @@
+package golaredge
+
+import (
+	"bytes"
+	"context"
+	"sync"
+)
+
+// CoalescingStats counts the calls of a client with coalescing, see WithCoalescing.
+type CoalescingStats struct {
+	// Requests is the number of calls that sent a request.
+	Requests int
+
+	// Coalesced is the number of calls that shared the response of an identical call in flight instead of sending a request.
+	Coalesced int
+}
+
+// WithCoalescing makes concurrent identical calls share a single request: a call made while the same request (by its canonical form)
+// is in flight waits for it and receives a copy of its response or its error, without using the daily quota. The request in flight is cancelled
+// only when every call waiting for it is cancelled. Responses are not kept once the request is done, see WithCache for that.
+func WithCoalescing() Option {
+	return func(client *Client) {
+		client.coalescer = &coalescer{flights: map[string]*flight{}}
+	}
+}
+
+// CoalescingStats returns the number of requests and coalesced calls so far, zero values if coalescing is disabled.
+func (client *Client) CoalescingStats() CoalescingStats {
+	if client.coalescer == nil {
+		return CoalescingStats{}
+	}
+
+	client.coalescer.mutex.Lock()
+	defer client.coalescer.mutex.Unlock()
+
+	return client.coalescer.stats
+}
+
+// flight is a request in flight and the calls waiting for it.
+type flight struct {
+	done    chan struct{}
+	bytes   []byte
+	err     error
+	waiting int
+	cancel  context.CancelFunc
+}
+
+type coalescer struct {
+	mutex   sync.Mutex
+	flights map[string]*flight
+	stats   CoalescingStats
+}
+
+// do returns the result of fetch for key, shared with the concurrent calls of the same key. A nil coalescer calls fetch directly.
+func (coalescer *coalescer) do(ctx context.Context, key string, fetch func(ctx context.Context) ([]byte, error)) ([]byte, error) {
+	if coalescer == nil {
+		return fetch(ctx)
+	}
+
+	coalescer.mutex.Lock()
+
+	current, ok := coalescer.flights[key]
+
+	if ok {
+		coalescer.stats.Coalesced++
+	} else {
+		// the request outlives the call starting it if other calls still wait for it
+		flightCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		current = &flight{done: make(chan struct{}), cancel: cancel}
+		coalescer.flights[key] = current
+		coalescer.stats.Requests++
+
+		go coalescer.run(flightCtx, key, current, fetch)
+	}
+
+	current.waiting++
+	coalescer.mutex.Unlock()
+
+	select {
+	case <-current.done:
+		// every call gets its own copy, the caller may modify it (e.g. an image)
+		return bytes.Clone(current.bytes), current.err
+	case <-ctx.Done():
+		coalescer.mutex.Lock()
+		defer coalescer.mutex.Unlock()
+
+		current.waiting--
+
+		if current.waiting == 0 {
+			current.cancel()
+			coalescer.forget(key, current)
+		}
+
+		return nil, ctx.Err()
+	}
+}
+
+func (coalescer *coalescer) run(ctx context.Context, key string, current *flight, fetch func(ctx context.Context) ([]byte, error)) {
+	current.bytes, current.err = fetch(ctx)
+
+	coalescer.mutex.Lock()
+	coalescer.forget(key, current)
+	coalescer.mutex.Unlock()
+
+	current.cancel()
+	close(current.done)
+}
+
+// forget removes current from the flights, so that the next call of key sends a new request.
+func (coalescer *coalescer) forget(key string, current *flight) {
+	if coalescer.flights[key] == current {
+		delete(coalescer.flights, key)
+	}
+}
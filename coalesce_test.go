@@ -0,0 +1,156 @@
+package golaredge
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// waitFor polls condition until it holds or a second passes.
+func waitFor(t *testing.T, condition func() bool) {
+	t.Helper()
+
+	for deadline := time.Now().Add(time.Second); !condition(); time.Sleep(time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met after 1s")
+		}
+	}
+}
+
+// TestClientCoalescing checks that concurrent identical calls share one request and that different calls do not.
+func TestClientCoalescing(t *testing.T) {
+	release := make(chan struct{})
+	var requests atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		<-release
+		w.Write([]byte(`{"siteCurrentPowerFlow":{"unit":"kW"}}`))
+	})
+	WithCoalescing()(client)
+
+	before := client.RemainingRequests()
+	var wait sync.WaitGroup
+
+	for range 5 {
+		wait.Add(1)
+
+		go func() {
+			defer wait.Done()
+
+			if flow, err := client.GetSitePowerFlow(t.Context(), SitePowerFlowParams{SiteId: 1}); err != nil || flow.SiteCurrentPowerFlow.Unit != "kW" {
+				t.Errorf("got %+v, %v", flow, err)
+			}
+		}()
+	}
+
+	waitFor(t, func() bool { return client.CoalescingStats().Coalesced == 4 })
+	close(release)
+	wait.Wait()
+
+	if _, err := client.GetSitePowerFlow(t.Context(), SitePowerFlowParams{SiteId: 2}); err != nil {
+		t.Fatal(err)
+	}
+
+	if stats := client.CoalescingStats(); requests.Load() != 2 || stats.Requests != 2 || stats.Coalesced != 4 {
+		t.Errorf("got %d requests, %+v", requests.Load(), stats)
+	}
+
+	if used := before - client.RemainingRequests(); used != 2 {
+		t.Errorf("used %d requests, want 2", used)
+	}
+}
+
+// TestClientCoalescingCancel checks that a shared request is only cancelled once every call waiting for it is cancelled.
+func TestClientCoalescingCancel(t *testing.T) {
+	release := make(chan struct{})
+	cancelled := make(chan struct{})
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+			w.Write([]byte(`{"siteCurrentPowerFlow":{"unit":"kW"}}`))
+		case <-r.Context().Done():
+			close(cancelled)
+		}
+	})
+	WithCoalescing()(client)
+
+	first, cancelFirst := context.WithCancel(t.Context())
+	results := make(chan error, 2)
+	call := func(ctx context.Context) {
+		_, err := client.GetSitePowerFlow(ctx, SitePowerFlowParams{SiteId: 1})
+		results <- err
+	}
+
+	go call(first)
+	waitFor(t, func() bool { return client.CoalescingStats().Requests == 1 })
+	go call(t.Context())
+	waitFor(t, func() bool { return client.CoalescingStats().Coalesced == 1 })
+
+	cancelFirst()
+
+	if err := <-results; !errors.Is(err, context.Canceled) {
+		t.Errorf("error = %v, want context.Canceled", err)
+	}
+
+	close(release)
+
+	if err := <-results; err != nil {
+		t.Errorf("error = %v, want the response of the shared request", err)
+	}
+
+	release = make(chan struct{})
+	last, cancelLast := context.WithCancel(t.Context())
+
+	go call(last)
+	waitFor(t, func() bool { return client.CoalescingStats().Requests == 2 })
+	cancelLast()
+
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Error("the request was not cancelled with the last call waiting for it")
+	}
+
+	<-results
+}
+
+// TestClientCoalescingCopies checks that the calls sharing a request do not share the bytes of its response.
+func TestClientCoalescingCopies(t *testing.T) {
+	release := make(chan struct{})
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.Write([]byte("\x89PNG"))
+	})
+	WithCoalescing()(client)
+
+	images := make([][]byte, 2)
+	var wait sync.WaitGroup
+
+	for i := range images {
+		wait.Add(1)
+
+		go func() {
+			defer wait.Done()
+
+			var err error
+
+			if images[i], err = client.GetSiteImage(t.Context(), SiteImageParams{SiteId: 1}); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+
+	waitFor(t, func() bool { return client.CoalescingStats().Coalesced == 1 })
+	close(release)
+	wait.Wait()
+
+	images[0][0] = 'x'
+
+	if string(images[1]) != "\x89PNG" {
+		t.Errorf("got %q, want the image unchanged by the other call", images[1])
+	}
+}
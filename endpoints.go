@@ -0,0 +1,661 @@
+package golaredge
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// timeUnitValue returns the timeUnit parameter of endpoint, DAY if timeUnit is not specified or not accepted (which is reported to validation).
+func timeUnitValue(endpoint Endpoint, timeUnit TimeUnit, validation *validation) string {
+	spec, _ := endpoint.Spec()
+
+	if timeUnit != 0 && !slices.Contains(spec.TimeUnits, timeUnit) {
+		validation.ignore("TimeUnit", timeUnit, "time unit not accepted by the endpoint, DAY is used instead")
+	}
+
+	return spec.TimeUnit(timeUnit).String()
+}
+
+// rangeLimit returns the maximum range of a request of endpoint with timeUnit.
+func rangeLimit(endpoint Endpoint, timeUnit TimeUnit) RangeLimit {
+	spec, _ := endpoint.Spec()
+
+	return spec.Limit(timeUnit)
+}
+
+// singleSiteRequest returns a request of an endpoint of a single site, e.g. EndpointSiteOverview.
+func singleSiteRequest(endpoint Endpoint, siteId int, validation *validation) Request {
+	if siteId < 0 {
+		validation.fail("SiteId", siteId, ErrInvalidSiteID, ErrInvalidSiteID.Error())
+	}
+
+	return Request{Endpoint: endpoint, PathParams: map[string]string{"siteId": strconv.Itoa(siteId)}, Query: url.Values{}}
+}
+
+// bulkSitesRequest returns a request of a bulk endpoint for the valid site ids, reporting negative and duplicated ones to validation.
+func bulkSitesRequest(endpoint Endpoint, siteIds []int, validation *validation) Request {
+	request := Request{Endpoint: endpoint, PathParams: map[string]string{"siteIds": ""}, Query: url.Values{}}
+
+	if len(siteIds) == 0 {
+		validation.fail("SiteIds", nil, ErrMissingSiteIDs, ErrMissingSiteIDs.Error())
+
+		return request
+	}
+
+	siteIdsFiltered := []int{}
+	siteIdsStrings := []string{}
+
+	for i := range siteIds {
+		siteId := siteIds[i]
+
+		if siteId < 0 {
+			validation.ignore("SiteIds", siteId, ErrInvalidSiteID.Error())
+
+			continue
+		}
+
+		if slices.Contains(siteIdsFiltered, siteId) {
+			validation.ignore("SiteIds", siteId, "duplicated site id")
+
+			continue
+		}
+
+		siteIdsFiltered = append(siteIdsFiltered, siteId)
+		siteIdsStrings = append(siteIdsStrings, strconv.Itoa(siteId))
+	}
+
+	if len(siteIdsFiltered) == 0 {
+		validation.fail("SiteIds", siteIds, ErrInvalidSiteID, "no valid site ids found")
+	}
+
+	if spec, _ := endpoint.Spec(); spec.MaxSiteIds > 0 && len(siteIdsFiltered) > spec.MaxSiteIds {
+		validation.fail("SiteIds", len(siteIdsFiltered), ErrTooManySiteIDs, fmt.Sprintf("the endpoint accepts at most %d site ids", spec.MaxSiteIds))
+	}
+
+	request.PathParams["siteIds"] = strings.Join(siteIdsStrings, ",")
+
+	return request
+}
+
+// parsedSitesRequest returns the request of single or bulk for sitesPath, which is either "site/{siteId}" or "sites/{siteId},{siteId},...".
+func parsedSitesRequest(sitesPath string, single Endpoint, bulk Endpoint) Request {
+	request := Request{PathParams: map[string]string{}, Query: url.Values{}}
+
+	if siteIds, ok := strings.CutPrefix(sitesPath, "sites/"); ok {
+		request.Endpoint = bulk
+		request.PathParams["siteIds"] = siteIds
+	} else if siteId, ok := strings.CutPrefix(sitesPath, "site/"); ok {
+		request.Endpoint = single
+		request.PathParams["siteId"] = siteId
+	} else {
+		// not a known endpoint, the path is sent as is
+		request.Endpoint = Endpoint(strings.Replace(string(single), "site/{siteId}", sitesPath, 1))
+	}
+
+	return request
+}
+
+// checkDates reports missing dates, an end before the start and a start after now, returning whether the range can be checked further.
+func checkDates(startField string, endField string, start time.Time, end time.Time, validation *validation) bool {
+	if start.IsZero() {
+		validation.fail(startField, nil, ErrMissingDates, ErrMissingDates.Error())
+	}
+
+	if end.IsZero() {
+		validation.fail(endField, nil, ErrMissingDates, ErrMissingDates.Error())
+	}
+
+	if start.IsZero() || end.IsZero() {
+		return false
+	}
+
+	if end.Before(start) {
+		validation.fail(endField, end, ErrInvalidDateRange, ErrInvalidDateRange.Error())
+
+		return false
+	}
+
+	if start.After(validation.now) {
+		validation.warn(startField, start, ErrFutureDates, "the range starts in the future, the API has no data for it")
+	}
+
+	return true
+}
+
+// checkRange is checkDates for the range parameters of endpoint, also reporting a range exceeding its limit for timeUnit.
+func checkRange(endpoint Endpoint, timeUnit TimeUnit, start time.Time, end time.Time, validation *validation) bool {
+	spec, _ := endpoint.Spec()
+
+	if !checkDates(spec.StartParam, spec.EndParam, start, end, validation) {
+		return false
+	}
+
+	if limit := spec.Limit(timeUnit); !limit.IsZero() && limit.end(start).Before(end) {
+		validation.fail(spec.EndParam, end, ErrDateRangeTooLarge, fmt.Sprintf("%s limits the range to %s", endpoint, limit))
+	}
+
+	return true
+}
+
+// pageValues adds the paging parameters of the list endpoints.
+func pageValues(size *int, startIndex *int, values url.Values, validation *validation) {
+	if size != nil {
+		if *size > -1 && *size < 101 {
+			values.Add("size", strconv.Itoa(*size))
+		} else {
+			validation.ignore("Size", *size, "size must be between 0 and 100")
+		}
+	}
+
+	if startIndex != nil {
+		if *startIndex > -1 {
+			values.Add("startIndex", strconv.Itoa(*startIndex))
+		} else {
+			validation.ignore("StartIndex", *startIndex, "start index must be >= 0")
+		}
+	}
+}
+
+// metersValue adds the meters parameter, reporting unknown meters and the meters in unavailable to validation.
+func metersValue(meters []MeterType, unavailable []MeterType, values url.Values, validation *validation) {
+	available := []MeterType{}
+
+	for i := range meters {
+		switch {
+		case !enumValid(meterTypeNames, meters[i]):
+			validation.ignore("Meters", meters[i], "unknown meter")
+		case slices.Contains(unavailable, meters[i]):
+			validation.ignore("Meters", meters[i], "meter is not available for this endpoint")
+		default:
+			available = append(available, meters[i])
+		}
+	}
+
+	if joined := joinEnums(available, meterTypeNames); joined != "" {
+		values.Add("meters", joined)
+	}
+}
+
+// sortValues adds the sorting parameters of the list endpoints.
+func sortValues[P ~int](property P, propertyNames []string, order SortOrder, values url.Values, validation *validation) {
+	if enumValid(propertyNames, property) {
+		values.Add("sortProperty", enumString(propertyNames, property))
+	} else if property != 0 {
+		validation.ignore("SortProperty", enumString(propertyNames, property), "unknown sort property")
+	}
+
+	if enumValid(sortOrderNames, order) {
+		values.Add("sortOrder", order.String())
+	} else if order != 0 {
+		validation.ignore("SortOrder", order, "unknown sort order")
+	}
+}
+
+// Site Data API
+
+func GetSiteListRequest(params SiteListParams) (Request, error) {
+	return lenientRequest(siteListRequest, params)
+}
+
+func siteListRequest(params SiteListParams, validation *validation) Request {
+	request := Request{Endpoint: EndpointSiteList, Query: url.Values{}}
+
+	pageValues(params.Size, params.StartIndex, request.Query, validation)
+
+	if params.SearchText != "" {
+		request.Query.Add("searchText", params.SearchText)
+	}
+
+	sortValues(params.SortProperty, siteSortPropertyNames, params.SortOrder, request.Query, validation)
+
+	for i := range params.Status {
+		if !enumValid(siteStatusNames, params.Status[i]) {
+			validation.ignore("Status", params.Status[i], "unknown site status")
+		}
+	}
+
+	if statuses := joinEnums(params.Status, siteStatusNames); statuses != "" {
+		request.Query.Add("status", statuses)
+	}
+
+	return request
+}
+
+func GetSiteRequest(params SiteParams) (Request, error) {
+	return lenientRequest(siteRequest, params)
+}
+
+func siteRequest(params SiteParams, validation *validation) Request {
+	return singleSiteRequest(EndpointSiteDetails, params.SiteId, validation)
+}
+
+func GetSiteDataStartAndEndDatesRequest(params SiteDataStartAndEndDatesParams) (Request, error) {
+	return lenientRequest(siteDataStartAndEndDatesRequest, params)
+}
+
+func siteDataStartAndEndDatesRequest(params SiteDataStartAndEndDatesParams, validation *validation) Request {
+	return singleSiteRequest(EndpointSiteDataPeriod, params.SiteId, validation)
+}
+
+func GetSiteDataStartAndEndDatesBulkRequest(params SiteDataStartAndEndDatesBulkParams) (Request, error) {
+	return lenientRequest(siteDataStartAndEndDatesBulkRequest, params)
+}
+
+func siteDataStartAndEndDatesBulkRequest(params SiteDataStartAndEndDatesBulkParams, validation *validation) Request {
+	return bulkSitesRequest(EndpointSiteDataPeriodBulk, params.SiteIds, validation)
+}
+
+// GetSiteEnergyWithParsedSitesRequest builds the energy request for sitesPath, which is either "site/{siteId}" or "sites/{siteId},{siteId},...".
+func GetSiteEnergyWithParsedSitesRequest(sitesPath string, startDate time.Time, endDate time.Time, timeUnit TimeUnit) (Request, error) {
+	return lenientRequest(func(params SiteEnergyParams, validation *validation) Request {
+		return siteEnergyValues(parsedSitesRequest(sitesPath, EndpointSiteEnergy, EndpointSiteEnergyBulk), params.StartDate, params.EndDate, params.TimeUnit, validation)
+	}, SiteEnergyParams{StartDate: startDate, EndDate: endDate, TimeUnit: timeUnit})
+}
+
+func siteEnergyValues(request Request, startDate time.Time, endDate time.Time, timeUnit TimeUnit, validation *validation) Request {
+	if !checkRange(EndpointSiteEnergy, timeUnit, startDate, endDate, validation) {
+		return request
+	}
+
+	request.Query.Add("timeUnit", timeUnitValue(EndpointSiteEnergy, timeUnit, validation))
+	request.Query.Add("startDate", encodeDate(startDate))
+	request.Query.Add("endDate", encodeDate(endDate))
+
+	return request
+}
+
+func GetSiteEnergyRequest(params SiteEnergyParams) (Request, error) {
+	return lenientRequest(siteEnergyRequest, params)
+}
+
+func siteEnergyRequest(params SiteEnergyParams, validation *validation) Request {
+	return siteEnergyValues(singleSiteRequest(EndpointSiteEnergy, params.SiteId, validation), params.StartDate, params.EndDate, params.TimeUnit, validation)
+}
+
+func GetSiteEnergyBulkRequest(params SiteEnergyBulkParams) (Request, error) {
+	return lenientRequest(siteEnergyBulkRequest, params)
+}
+
+func siteEnergyBulkRequest(params SiteEnergyBulkParams, validation *validation) Request {
+	return siteEnergyValues(bulkSitesRequest(EndpointSiteEnergyBulk, params.SiteIds, validation), params.StartDate, params.EndDate, params.TimeUnit, validation)
+}
+
+// GetSiteEnergyTimePeriodWithParsedSitesRequest builds the timeFrameEnergy request for sitesPath, which is either "site/{siteId}" or "sites/{siteId},{siteId},...".
+func GetSiteEnergyTimePeriodWithParsedSitesRequest(sitesPath string, startDate time.Time, endDate time.Time) (Request, error) {
+	return lenientRequest(func(params SiteEnergyTimePeriodParams, validation *validation) Request {
+		return siteEnergyTimePeriodValues(parsedSitesRequest(sitesPath, EndpointSiteTimeFrameEnergy, EndpointSiteTimeFrameEnergyBulk), params.StartDate, params.EndDate, validation)
+	}, SiteEnergyTimePeriodParams{StartDate: startDate, EndDate: endDate})
+}
+
+func siteEnergyTimePeriodValues(request Request, startDate time.Time, endDate time.Time, validation *validation) Request {
+	if !checkRange(EndpointSiteTimeFrameEnergy, 0, startDate, endDate, validation) {
+		return request
+	}
+
+	request.Query.Add("startDate", encodeDate(startDate))
+	request.Query.Add("endDate", encodeDate(endDate))
+
+	return request
+}
+
+func GetSiteEnergyTimePeriodRequest(params SiteEnergyTimePeriodParams) (Request, error) {
+	return lenientRequest(siteEnergyTimePeriodRequest, params)
+}
+
+func siteEnergyTimePeriodRequest(params SiteEnergyTimePeriodParams, validation *validation) Request {
+	return siteEnergyTimePeriodValues(singleSiteRequest(EndpointSiteTimeFrameEnergy, params.SiteId, validation), params.StartDate, params.EndDate, validation)
+}
+
+func GetSiteEnergyTimePeriodBulkRequest(params SiteEnergyTimePeriodBulkParams) (Request, error) {
+	return lenientRequest(siteEnergyTimePeriodBulkRequest, params)
+}
+
+func siteEnergyTimePeriodBulkRequest(params SiteEnergyTimePeriodBulkParams, validation *validation) Request {
+	return siteEnergyTimePeriodValues(bulkSitesRequest(EndpointSiteTimeFrameEnergyBulk, params.SiteIds, validation), params.StartDate, params.EndDate, validation)
+}
+
+// GetSitePowerWithParsedSitesRequest builds the power request for sitesPath, which is either "site/{siteId}" or "sites/{siteId},{siteId},...".
+func GetSitePowerWithParsedSitesRequest(sitesPath string, startTime time.Time, endTime time.Time) (Request, error) {
+	return lenientRequest(func(params SitePowerParams, validation *validation) Request {
+		return sitePowerValues(parsedSitesRequest(sitesPath, EndpointSitePower, EndpointSitePowerBulk), params.StartTime, params.EndTime, validation)
+	}, SitePowerParams{StartTime: startTime, EndTime: endTime})
+}
+
+func sitePowerValues(request Request, startTime time.Time, endTime time.Time, validation *validation) Request {
+	if !checkRange(EndpointSitePower, 0, startTime, endTime, validation) {
+		return request
+	}
+
+	request.Query.Add("startTime", encodeDateTime(startTime))
+	request.Query.Add("endTime", encodeDateTime(endTime))
+
+	return request
+}
+
+func GetSitePowerRequest(params SitePowerParams) (Request, error) {
+	return lenientRequest(sitePowerRequest, params)
+}
+
+func sitePowerRequest(params SitePowerParams, validation *validation) Request {
+	return sitePowerValues(singleSiteRequest(EndpointSitePower, params.SiteId, validation), params.StartTime, params.EndTime, validation)
+}
+
+func GetSitePowerBulkRequest(params SitePowerBulkParams) (Request, error) {
+	return lenientRequest(sitePowerBulkRequest, params)
+}
+
+func sitePowerBulkRequest(params SitePowerBulkParams, validation *validation) Request {
+	return sitePowerValues(bulkSitesRequest(EndpointSitePowerBulk, params.SiteIds, validation), params.StartTime, params.EndTime, validation)
+}
+
+func GetSiteOverviewRequest(params SiteOverviewParams) (Request, error) {
+	return lenientRequest(siteOverviewRequest, params)
+}
+
+func siteOverviewRequest(params SiteOverviewParams, validation *validation) Request {
+	return singleSiteRequest(EndpointSiteOverview, params.SiteId, validation)
+}
+
+func GetSiteOverviewBulkRequest(params SiteOverviewBulkParams) (Request, error) {
+	return lenientRequest(siteOverviewBulkRequest, params)
+}
+
+func siteOverviewBulkRequest(params SiteOverviewBulkParams, validation *validation) Request {
+	return bulkSitesRequest(EndpointSiteOverviewBulk, params.SiteIds, validation)
+}
+
+func GetSitePowerDetailedRequest(params SitePowerDetailedParams) (Request, error) {
+	return lenientRequest(sitePowerDetailedRequest, params)
+}
+
+func sitePowerDetailedRequest(params SitePowerDetailedParams, validation *validation) Request {
+	request := singleSiteRequest(EndpointSitePowerDetails, params.SiteId, validation)
+
+	metersValue(params.Meters, nil, request.Query, validation)
+
+	if !checkRange(EndpointSitePowerDetails, 0, params.StartTime, params.EndTime, validation) {
+		return request
+	}
+
+	request.Query.Add("startTime", encodeDateTime(params.StartTime))
+	request.Query.Add("endTime", encodeDateTime(params.EndTime))
+
+	return request
+}
+
+func GetSiteEnergyDetailedRequest(params SiteEnergyDetailedParams) (Request, error) {
+	return lenientRequest(siteEnergyDetailedRequest, params)
+}
+
+func siteEnergyDetailedRequest(params SiteEnergyDetailedParams, validation *validation) Request {
+	request := singleSiteRequest(EndpointSiteEnergyDetails, params.SiteId, validation)
+
+	request.Query.Add("timeUnit", timeUnitValue(EndpointSiteEnergyDetails, params.TimeUnit, validation))
+	metersValue(params.Meters, nil, request.Query, validation)
+
+	if !checkRange(EndpointSiteEnergyDetails, params.TimeUnit, params.StartTime, params.EndTime, validation) {
+		return request
+	}
+
+	request.Query.Add("startTime", encodeDateTime(params.StartTime))
+	request.Query.Add("endTime", encodeDateTime(params.EndTime))
+
+	return request
+}
+
+func GetSitePowerFlowRequest(params SitePowerFlowParams) (Request, error) {
+	return lenientRequest(sitePowerFlowRequest, params)
+}
+
+func sitePowerFlowRequest(params SitePowerFlowParams, validation *validation) Request {
+	return singleSiteRequest(EndpointSiteCurrentPowerFlow, params.SiteId, validation)
+}
+
+func GetStorageInformationRequest(params StorageInformationParams) (Request, error) {
+	return lenientRequest(storageInformationRequest, params)
+}
+
+func storageInformationRequest(params StorageInformationParams, validation *validation) Request {
+	request := singleSiteRequest(EndpointSiteStorageData, params.SiteId, validation)
+
+	if len(params.Serials) > 0 {
+		serials := []string{}
+
+		for i := range params.Serials {
+			if slices.Contains(serials, params.Serials[i]) {
+				validation.ignore("Serials", params.Serials[i], "duplicated serial number")
+
+				continue
+			}
+
+			serials = append(serials, params.Serials[i])
+		}
+
+		request.Query.Add("serials", strings.Join(serials, ","))
+	}
+
+	if !checkRange(EndpointSiteStorageData, 0, params.StartTime, params.EndTime, validation) {
+		return request
+	}
+
+	request.Query.Add("startTime", encodeDateTime(params.StartTime))
+	request.Query.Add("endTime", encodeDateTime(params.EndTime))
+
+	return request
+}
+
+// imageRequest returns the request of endpoint, or of named if the image has a name.
+func imageRequest(endpoint Endpoint, named Endpoint, params SiteImageParams, validation *validation) Request {
+	if params.Name == "" {
+		return singleSiteRequest(endpoint, params.SiteId, validation)
+	}
+
+	request := singleSiteRequest(named, params.SiteId, validation)
+	request.PathParams["name"] = params.Name
+
+	return request
+}
+
+func GetSiteImageRequest(params SiteImageParams) (Request, error) {
+	return lenientRequest(siteImageRequest, params)
+}
+
+func siteImageRequest(params SiteImageParams, validation *validation) Request {
+	request := imageRequest(EndpointSiteImage, EndpointSiteImageName, params, validation)
+
+	if params.MaxHeight != nil {
+		if *params.MaxHeight <= 0 {
+			validation.fail("MaxHeight", *params.MaxHeight, ErrInvalidImageSize, "invalid max height")
+		}
+
+		request.Query.Add("maxHeight", strconv.Itoa(*params.MaxHeight))
+	}
+
+	if params.MaxWidth != nil {
+		if *params.MaxWidth <= 0 {
+			validation.fail("MaxWidth", *params.MaxWidth, ErrInvalidImageSize, "invalid max width")
+		}
+
+		request.Query.Add("maxWidth", strconv.Itoa(*params.MaxWidth))
+	}
+
+	if params.Hash != nil {
+		if params.MaxHeight != nil || params.MaxWidth != nil {
+			validation.ignore("Hash", *params.Hash, "the API ignores the hash when the max width or height is set")
+		}
+
+		request.Query.Add("hash", strconv.Itoa(*params.Hash))
+	}
+
+	return request
+}
+
+func GetSiteEnvironmentalBenefitsRequest(params SiteEnvironmentalBenefitsParams) (Request, error) {
+	return lenientRequest(siteEnvironmentalBenefitsRequest, params)
+}
+
+func siteEnvironmentalBenefitsRequest(params SiteEnvironmentalBenefitsParams, validation *validation) Request {
+	request := singleSiteRequest(EndpointSiteEnvironmentalBenefits, params.SiteId, validation)
+
+	if enumValid(systemUnitsNames, params.SystemUnits) {
+		request.Query.Add("systemUnits", params.SystemUnits.String())
+	} else if params.SystemUnits != 0 {
+		validation.ignore("SystemUnits", params.SystemUnits, "unknown system units")
+	}
+
+	return request
+}
+
+func GetInstallerImageRequest(params SiteImageParams) (Request, error) {
+	return lenientRequest(installerImageRequest, params)
+}
+
+func installerImageRequest(params SiteImageParams, validation *validation) Request {
+	return imageRequest(EndpointSiteInstallerImage, EndpointSiteInstallerImageName, params, validation)
+}
+
+// Site Equipment API
+
+func GetComponentsListRequest(params ComponentsListParams) (Request, error) {
+	return lenientRequest(componentsListRequest, params)
+}
+
+func componentsListRequest(params ComponentsListParams, validation *validation) Request {
+	return singleSiteRequest(EndpointComponentsList, params.SiteId, validation)
+}
+
+func GetInventoryRequest(params InventoryParams) (Request, error) {
+	return lenientRequest(inventoryRequest, params)
+}
+
+func inventoryRequest(params InventoryParams, validation *validation) Request {
+	return singleSiteRequest(EndpointSiteInventory, params.SiteId, validation)
+}
+
+// equipmentRequest returns a request of an endpoint of a single inverter, e.g. EndpointInverterTechnicalData.
+func equipmentRequest(endpoint Endpoint, siteId int, serialNumber string, validation *validation) Request {
+	request := singleSiteRequest(endpoint, siteId, validation)
+
+	if serialNumber == "" {
+		validation.fail("SerialNumber", nil, ErrMissingSerialNumber, ErrMissingSerialNumber.Error())
+	}
+
+	request.PathParams["serialNumber"] = serialNumber
+
+	return request
+}
+
+func GetInverterTechnicalDataRequest(params InverterTechnicalDataParams) (Request, error) {
+	return lenientRequest(inverterTechnicalDataRequest, params)
+}
+
+func inverterTechnicalDataRequest(params InverterTechnicalDataParams, validation *validation) Request {
+	request := equipmentRequest(EndpointInverterTechnicalData, params.SiteId, params.SerialNumber, validation)
+
+	if !checkRange(EndpointInverterTechnicalData, 0, params.StartTime, params.EndTime, validation) {
+		return request
+	}
+
+	request.Query.Add("startTime", encodeDateTime(params.StartTime))
+	request.Query.Add("endTime", encodeDateTime(params.EndTime))
+
+	return request
+}
+
+func GetEquipmentChangeLogRequest(params EquipmentChangeLogParams) (Request, error) {
+	return lenientRequest(equipmentChangeLogRequest, params)
+}
+
+func equipmentChangeLogRequest(params EquipmentChangeLogParams, validation *validation) Request {
+	return equipmentRequest(EndpointEquipmentChangeLog, params.SiteId, params.SerialNumber, validation)
+}
+
+// Account List API
+
+func GetAccountListRequest(params AccountListParams) (Request, error) {
+	return lenientRequest(accountListRequest, params)
+}
+
+func accountListRequest(params AccountListParams, validation *validation) Request {
+	request := Request{Endpoint: EndpointAccountList, Query: url.Values{}}
+
+	pageValues(params.Size, params.StartIndex, request.Query, validation)
+
+	if params.SearchText != "" {
+		request.Query.Add("searchText", params.SearchText)
+	}
+
+	sortValues(params.SortProperty, accountSortPropertyNames, params.SortOrder, request.Query, validation)
+
+	return request
+}
+
+// Meters API
+
+func GetMetersDataRequest(params MetersDataParams) (Request, error) {
+	return lenientRequest(metersDataRequest, params)
+}
+
+func metersDataRequest(params MetersDataParams, validation *validation) Request {
+	request := singleSiteRequest(EndpointSiteMeters, params.SiteId, validation)
+
+	request.Query.Add("timeUnit", timeUnitValue(EndpointSiteMeters, params.TimeUnit, validation))
+
+	// the meters endpoint has no self consumption meter
+	metersValue(params.Meters, []MeterType{MeterSelfConsumption}, request.Query, validation)
+
+	if !checkRange(EndpointSiteMeters, params.TimeUnit, params.StartTime, params.EndTime, validation) {
+		return request
+	}
+
+	request.Query.Add("startTime", encodeDateTime(params.StartTime))
+	request.Query.Add("endTime", encodeDateTime(params.EndTime))
+
+	return request
+}
+
+// Sensors API
+
+func GetSensorsListRequest(params SensorsListParams) (Request, error) {
+	return lenientRequest(sensorsListRequest, params)
+}
+
+func sensorsListRequest(params SensorsListParams, validation *validation) Request {
+	return singleSiteRequest(EndpointSensorsList, params.SiteId, validation)
+}
+
+func GetSensorDataRequest(params SensorDataParams) (Request, error) {
+	return lenientRequest(sensorDataRequest, params)
+}
+
+func sensorDataRequest(params SensorDataParams, validation *validation) Request {
+	request := singleSiteRequest(EndpointSiteSensors, params.SiteId, validation)
+
+	if !checkRange(EndpointSiteSensors, 0, params.StartDate, params.EndDate, validation) {
+		return request
+	}
+
+	request.Query.Add("startDate", encodeDateTime(params.StartDate))
+	request.Query.Add("endDate", encodeDateTime(params.EndDate))
+
+	return request
+}
+
+// API Versions
+
+var (
+	currentVersionRequest   = Request{Endpoint: EndpointCurrentVersion, Auth: AuthNone}
+	supportedVersionRequest = Request{Endpoint: EndpointSupportedVersion, Auth: AuthNone}
+)
+
+func GetCurrentVersionRequest() Request {
+	return currentVersionRequest
+}
+
+func GetSupportedVersionRequest() Request {
+	return supportedVersionRequest
+}
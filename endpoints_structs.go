@@ -0,0 +1,257 @@
+package golaredge
+
+import "time"
+
+// Site Data API
+
+// SiteListParams represents the parameters for the GetSiteList endpoint function.
+// if Size is nil, the default value of 100 will be used.
+// if StartIndex is nil, the default value of 0 will be used.
+// if SearchText is "", it will be ignored.
+// if SortProperty or SortOrder is not specified, the API default is used.
+// if Status is empty, the API returns the Active and Pending sites.
+type SiteListParams struct {
+	Size         *int
+	StartIndex   *int
+	SearchText   string
+	SortProperty SiteSortProperty
+	SortOrder    SortOrder
+	Status       []SiteStatus
+}
+
+type SiteParams struct {
+	SiteId int
+}
+
+type SiteDataStartAndEndDatesParams struct {
+	SiteId int
+}
+
+type SiteDataStartAndEndDatesBulkParams struct {
+	SiteIds []int
+}
+
+// SiteEnergyParams represents the parameters for the GetSiteEnergy endpoint function.
+// if TimeUnit is not specified, it will default to DAY.
+type SiteEnergyParams struct {
+	SiteId int
+
+	// Precision: 2006-01-02
+	StartDate time.Time
+
+	// Precision: 2006-01-02
+	EndDate time.Time
+
+	TimeUnit TimeUnit
+}
+
+// SiteEnergyBulkParams represents the parameters for the GetSiteBulkEnergy endpoint function.
+// if TimeUnit is not specified, it will default to DAY.
+type SiteEnergyBulkParams struct {
+	SiteIds []int
+
+	// Precision: 2006-01-02
+	StartDate time.Time
+
+	// Precision: 2006-01-02
+	EndDate time.Time
+
+	TimeUnit TimeUnit
+}
+
+// SiteEnergyTimePeriodParams returns total energy on generated at two specific points in time (the StartDate and EndDate). This metric is pretty useless in my opinion (but it exists).
+type SiteEnergyTimePeriodParams struct {
+	SiteId int
+
+	// Precision: 2006-01-02
+	StartDate time.Time
+
+	// Precision: 2006-01-02
+	EndDate time.Time
+}
+
+type SiteEnergyTimePeriodBulkParams struct {
+	SiteIds []int
+
+	// Precision: 2006-01-02
+	StartDate time.Time
+
+	// Precision: 2006-01-02
+	EndDate time.Time
+}
+
+// SitePowerParams return the site power measurements in 15 minutes resolution
+type SitePowerParams struct {
+	SiteId int
+
+	// Precision: 2006-01-02 11:00:00
+	StartTime time.Time
+
+	// Precision: 2006-01-02 11:00:00
+	EndTime time.Time
+}
+
+type SitePowerBulkParams struct {
+	SiteIds []int
+
+	// Precision: 2006-01-02 11:00:00
+	StartTime time.Time
+
+	// Precision: 2006-01-02 11:00:00
+	EndTime time.Time
+}
+
+type SiteOverviewParams struct {
+	SiteId int
+}
+
+type SiteOverviewBulkParams struct {
+	SiteIds []int
+}
+
+// SitePowerDetailedParams returns detailed site power measurements from meters such as consumption, export (feed-in), import (purchase), etc.
+// Meters is optional. If not specified, all meter readings are returned.
+type SitePowerDetailedParams struct {
+	SiteId int
+
+	// Precision: 2006-01-02 11:00:00
+	StartTime time.Time
+
+	// Precision: 2006-01-02 11:00:00
+	EndTime time.Time
+
+	Meters []MeterType
+}
+
+type SiteEnergyDetailedParams struct {
+	SiteId int
+
+	// Precision: 2006-01-02 11:00:00
+	StartTime time.Time
+
+	// Precision: 2006-01-02 11:00:00
+	EndTime time.Time
+
+	TimeUnit TimeUnit
+	Meters   []MeterType
+}
+
+type SitePowerFlowParams struct {
+	SiteId int
+}
+
+// StorageInformationParams returns storage information about the batteries
+type StorageInformationParams struct {
+	SiteId int
+
+	// Precision: 2006-01-02 11:00:00
+	StartTime time.Time
+
+	// Precision: 2006-01-02 11:00:00
+	EndTime time.Time
+
+	Serials []string
+}
+
+// SiteImageParams returns the site image (uploaded by the user).
+// The Name parameter will be used as the name for the downloaded file (optional).
+// Specifying MaxWidth or MaxHeight will rescale the image using the original upload size, but maintaining the ratio. Setting either will ignore the Hash parameter.
+// When the Hash parameter is set the API will return a 304 error if the uploaded file has a different hash than the one provided.
+type SiteImageParams struct {
+	SiteId    int
+	Name      string
+	MaxWidth  *int
+	MaxHeight *int
+	Hash      *int
+}
+
+// SiteEnvironmentalBenefitsParams returns the list of environmental benefits associated with the site energy production.
+// if SystemUnits is not specified, the system units of the user are used.
+type SiteEnvironmentalBenefitsParams struct {
+	SiteId      int
+	SystemUnits SystemUnits
+}
+
+type InstallerLogoParams struct {
+	SiteId int
+	Name   string
+}
+
+// Site Equipment API
+
+type ComponentsListParams struct {
+	SiteId int
+}
+
+type InventoryParams struct {
+	SiteId int
+}
+
+// InverterTechnicalDataParams returns specific inverter data for a given timeframe.
+// All parameters are required. The difference between StartTime and EndTime should not exceed one week.
+type InverterTechnicalDataParams struct {
+	SiteId       int
+	SerialNumber string
+
+	// Precision: 2006-01-02 11:00:00
+	StartTime time.Time
+
+	// Precision: 2006-01-02 11:00:00
+	EndTime time.Time
+}
+
+type EquipmentChangeLogParams struct {
+	SiteId       int
+	SerialNumber string
+}
+
+// Account List API
+
+// AccountListParams the account and list of sub-accounts related to the given token.
+// if Size is nil, the default value of 100 will be used.
+// if StartIndex is nil, the default value of 0 will be used.
+// if SearchText is "", it will be ignored.
+// if SortProperty or SortOrder is not specified, the API default is used.
+type AccountListParams struct {
+	Size         *int
+	StartIndex   *int
+	SearchText   string
+	SortProperty AccountSortProperty
+	SortOrder    SortOrder
+}
+
+// Meters API
+
+// MetersDataParams returns for each meter on site its lifetime energy reading, metadata and the device to which it’s connected to
+// if TimeUnit is not specified, it will default to DAY.
+type MetersDataParams struct {
+	SiteId int
+
+	TimeUnit TimeUnit
+
+	// Precision: 2006-01-02 11:00:00
+	StartTime time.Time
+
+	// Precision: 2006-01-02 11:00:00
+	EndTime time.Time
+
+	Meters []MeterType
+}
+
+// Sensors API
+
+type SensorsListParams struct {
+	SiteId int
+}
+
+// SensorDataParams returns the data of all the sensors in the site, by the gateway they are connected to.
+// StartDate and EndDate should not exceed one week as this API is limited as such.
+type SensorDataParams struct {
+	SiteId int
+
+	// Precision: 2006-01-02 11:00:00
+	StartDate time.Time
+
+	// Precision: 2006-01-02 11:00:00
+	EndDate time.Time
+}
@@ -0,0 +1,288 @@
+package golaredge
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// The enums below are the values the API accepts for the parameters of the same name. Their zero value means
+// the parameter is not specified, so the API default is used. String returns the value as sent to the API,
+// Parse* accepts it in any case (e.g. "feedin" for MeterFeedIn) to share the vocabulary with CLIs and configuration files.
+
+// TimeUnit is the aggregation period of the energy, energyDetails and meters endpoints. The API defaults to DAY.
+type TimeUnit int
+
+const (
+	TimeUnitQuarterOfAnHour TimeUnit = iota + 1
+	TimeUnitHour
+	TimeUnitDay
+	TimeUnitWeek
+	TimeUnitMonth
+	TimeUnitYear
+)
+
+var timeUnitNames = []string{"", "QUARTER_OF_AN_HOUR", "HOUR", "DAY", "WEEK", "MONTH", "YEAR"}
+
+// MeterType is a meter of the powerDetails, energyDetails and meters endpoints.
+type MeterType int
+
+const (
+	MeterProduction MeterType = iota + 1
+	MeterConsumption
+	MeterSelfConsumption
+	MeterFeedIn
+	MeterPurchased
+)
+
+var meterTypeNames = []string{"", "Production", "Consumption", "SelfConsumption", "FeedIn", "Purchased"}
+
+// SiteSortProperty is the property the sites of the site list are sorted by.
+type SiteSortProperty int
+
+const (
+	SiteSortByName SiteSortProperty = iota + 1
+	SiteSortByCountry
+	SiteSortByState
+	SiteSortByCity
+	SiteSortByAddress
+	SiteSortByZip
+	SiteSortByStatus
+	SiteSortByPeakPower
+	SiteSortByInstallationDate
+	SiteSortByAmount
+	SiteSortByMaxSeverity
+	SiteSortByCreationTime
+)
+
+var siteSortPropertyNames = []string{"", "Name", "Country", "State", "City", "Address", "Zip", "Status", "PeakPower", "InstallationDate", "Amount", "MaxSeverity", "CreationTime"}
+
+// AccountSortProperty is the property the accounts of the account list are sorted by.
+type AccountSortProperty int
+
+const (
+	AccountSortByName AccountSortProperty = iota + 1
+	AccountSortByCountry
+	AccountSortByCity
+	AccountSortByAddress
+	AccountSortByZip
+	AccountSortByFax
+	AccountSortByPhone
+	AccountSortByNotes
+)
+
+// The API documents the account sort properties other than Name in lower case.
+var accountSortPropertyNames = []string{"", "Name", "country", "city", "address", "zip", "fax", "phone", "notes"}
+
+// SortOrder is the order of the site and account lists. The API defaults to ascending.
+type SortOrder int
+
+const (
+	SortAscending SortOrder = iota + 1
+	SortDescending
+)
+
+var sortOrderNames = []string{"", "ASC", "DESC"}
+
+// SiteStatus filters the site list by the status of the sites. The API defaults to Active and Pending.
+type SiteStatus int
+
+const (
+	SiteStatusActive SiteStatus = iota + 1
+	SiteStatusPending
+	SiteStatusDisabled
+	SiteStatusAll
+)
+
+var siteStatusNames = []string{"", "Active", "Pending", "Disabled", "All"}
+
+// SystemUnits is the unit system of the environmental benefits. The API defaults to the units of the user.
+type SystemUnits int
+
+const (
+	SystemUnitsMetrics SystemUnits = iota + 1
+	SystemUnitsImperial
+)
+
+var systemUnitsNames = []string{"", "Metrics", "Imperial"}
+
+func (timeUnit TimeUnit) String() string {
+	return enumString(timeUnitNames, timeUnit)
+}
+
+func (meter MeterType) String() string {
+	return enumString(meterTypeNames, meter)
+}
+
+func (property SiteSortProperty) String() string {
+	return enumString(siteSortPropertyNames, property)
+}
+
+func (property AccountSortProperty) String() string {
+	return enumString(accountSortPropertyNames, property)
+}
+
+func (order SortOrder) String() string {
+	return enumString(sortOrderNames, order)
+}
+
+func (status SiteStatus) String() string {
+	return enumString(siteStatusNames, status)
+}
+
+func (units SystemUnits) String() string {
+	return enumString(systemUnitsNames, units)
+}
+
+func ParseTimeUnit(text string) (TimeUnit, error) {
+	return parseEnum[TimeUnit]("time unit", timeUnitNames, text)
+}
+
+func ParseMeterType(text string) (MeterType, error) {
+	return parseEnum[MeterType]("meter", meterTypeNames, text)
+}
+
+func ParseSiteSortProperty(text string) (SiteSortProperty, error) {
+	return parseEnum[SiteSortProperty]("site sort property", siteSortPropertyNames, text)
+}
+
+func ParseAccountSortProperty(text string) (AccountSortProperty, error) {
+	return parseEnum[AccountSortProperty]("account sort property", accountSortPropertyNames, text)
+}
+
+func ParseSortOrder(text string) (SortOrder, error) {
+	return parseEnum[SortOrder]("sort order", sortOrderNames, text)
+}
+
+func ParseSiteStatus(text string) (SiteStatus, error) {
+	return parseEnum[SiteStatus]("site status", siteStatusNames, text)
+}
+
+func ParseSystemUnits(text string) (SystemUnits, error) {
+	return parseEnum[SystemUnits]("system units", systemUnitsNames, text)
+}
+
+func (timeUnit TimeUnit) MarshalText() ([]byte, error) {
+	return marshalEnum(timeUnitNames, timeUnit)
+}
+
+func (meter MeterType) MarshalText() ([]byte, error) {
+	return marshalEnum(meterTypeNames, meter)
+}
+
+func (property SiteSortProperty) MarshalText() ([]byte, error) {
+	return marshalEnum(siteSortPropertyNames, property)
+}
+
+func (property AccountSortProperty) MarshalText() ([]byte, error) {
+	return marshalEnum(accountSortPropertyNames, property)
+}
+
+func (order SortOrder) MarshalText() ([]byte, error) {
+	return marshalEnum(sortOrderNames, order)
+}
+
+func (status SiteStatus) MarshalText() ([]byte, error) {
+	return marshalEnum(siteStatusNames, status)
+}
+
+func (units SystemUnits) MarshalText() ([]byte, error) {
+	return marshalEnum(systemUnitsNames, units)
+}
+
+func (timeUnit *TimeUnit) UnmarshalText(text []byte) error {
+	return unmarshalEnum(timeUnit, ParseTimeUnit, text)
+}
+
+func (meter *MeterType) UnmarshalText(text []byte) error {
+	return unmarshalEnum(meter, ParseMeterType, text)
+}
+
+func (property *SiteSortProperty) UnmarshalText(text []byte) error {
+	return unmarshalEnum(property, ParseSiteSortProperty, text)
+}
+
+func (property *AccountSortProperty) UnmarshalText(text []byte) error {
+	return unmarshalEnum(property, ParseAccountSortProperty, text)
+}
+
+func (order *SortOrder) UnmarshalText(text []byte) error {
+	return unmarshalEnum(order, ParseSortOrder, text)
+}
+
+func (status *SiteStatus) UnmarshalText(text []byte) error {
+	return unmarshalEnum(status, ParseSiteStatus, text)
+}
+
+func (units *SystemUnits) UnmarshalText(text []byte) error {
+	return unmarshalEnum(units, ParseSystemUnits, text)
+}
+
+func enumValid[T ~int](names []string, value T) bool {
+	return value > 0 && int(value) < len(names)
+}
+
+// enumString returns the name of value, "" for the zero value and e.g. "TimeUnit(9)" for a value out of range.
+func enumString[T ~int](names []string, value T) string {
+	if value == 0 || enumValid(names, value) {
+		return names[value]
+	}
+
+	return fmt.Sprintf("%T(%d)", value, int(value))
+}
+
+// parseEnum returns the value named text, ignoring case. The empty text is the zero value.
+func parseEnum[T ~int](kind string, names []string, text string) (T, error) {
+	for i := range names {
+		if strings.EqualFold(names[i], text) {
+			return T(i), nil
+		}
+	}
+
+	return 0, fmt.Errorf("%w: %s %q", ErrUnknownValue, kind, text)
+}
+
+func marshalEnum[T ~int](names []string, value T) ([]byte, error) {
+	if value != 0 && !enumValid(names, value) {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownValue, enumString(names, value))
+	}
+
+	return []byte(names[value]), nil
+}
+
+func unmarshalEnum[T ~int](value *T, parse func(string) (T, error), text []byte) error {
+	parsed, err := parse(string(text))
+
+	if err != nil {
+		return err
+	}
+
+	*value = parsed
+
+	return nil
+}
+
+// joinEnums returns the valid values in their declaration order without duplicates, separated by commas, e.g. "Production,FeedIn".
+// Values out of range are ignored.
+func joinEnums[T interface {
+	~int
+	String() string
+}](values []T, names []string) string {
+	sorted := []T{}
+
+	for i := range values {
+		if enumValid(names, values[i]) {
+			sorted = append(sorted, values[i])
+		}
+	}
+
+	slices.Sort(sorted)
+	sorted = slices.Compact(sorted)
+	parts := make([]string, len(sorted))
+
+	for i := range sorted {
+		parts[i] = sorted[i].String()
+	}
+
+	return strings.Join(parts, ",")
+}
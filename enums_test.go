@@ -0,0 +1,105 @@
+package golaredge
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseEnums(t *testing.T) {
+	timeUnit, err := ParseTimeUnit("quarter_of_an_hour")
+
+	if err != nil || timeUnit != TimeUnitQuarterOfAnHour {
+		t.Errorf("got %v, %v, want %v", timeUnit, err, TimeUnitQuarterOfAnHour)
+	}
+
+	meter, err := ParseMeterType("feedin")
+
+	if err != nil || meter != MeterFeedIn {
+		t.Errorf("got %v, %v, want %v", meter, err, MeterFeedIn)
+	}
+
+	property, err := ParseAccountSortProperty("Country")
+
+	if err != nil || property.String() != "country" {
+		t.Errorf("got %v, %v, want country", property, err)
+	}
+
+	if _, err := ParseSystemUnits("Imerial"); !errors.Is(err, ErrUnknownValue) {
+		t.Errorf("got %v, want %v", err, ErrUnknownValue)
+	}
+
+	if TimeUnit(42).String() != "golaredge.TimeUnit(42)" {
+		t.Errorf("got %q for an unknown time unit", TimeUnit(42).String())
+	}
+}
+
+func TestEnumsText(t *testing.T) {
+	type config struct {
+		TimeUnit TimeUnit
+		Status   []SiteStatus
+	}
+
+	data, err := json.Marshal(config{TimeUnit: TimeUnitWeek, Status: []SiteStatus{SiteStatusActive, SiteStatusDisabled}})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(data) != `{"TimeUnit":"WEEK","Status":["Active","Disabled"]}` {
+		t.Errorf("got %s", data)
+	}
+
+	result := config{}
+
+	if err := json.Unmarshal([]byte(`{"TimeUnit":"hour","Status":["pending"]}`), &result); err != nil {
+		t.Fatal(err)
+	}
+
+	if result.TimeUnit != TimeUnitHour || len(result.Status) != 1 || result.Status[0] != SiteStatusPending {
+		t.Errorf("got %+v", result)
+	}
+
+	if err := json.Unmarshal([]byte(`{"TimeUnit":"DAYY"}`), &result); !errors.Is(err, ErrUnknownValue) {
+		t.Errorf("got %v, want %v", err, ErrUnknownValue)
+	}
+}
+
+func TestEnumParameters(t *testing.T) {
+	request, err := GetSiteListRequest(SiteListParams{
+		SortProperty: SiteSortByPeakPower,
+		Status:       []SiteStatus{SiteStatusDisabled, SiteStatusActive, SiteStatusDisabled},
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	query := request.Query
+
+	if query.Get("sortProperty") != "PeakPower" || query.Get("status") != "Active,Disabled" {
+		t.Errorf("got %v", query)
+	}
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	request, err = GetSiteEnergyRequest(SiteEnergyParams{SiteId: 1, StartDate: start, EndDate: start.AddDate(0, 0, 7), TimeUnit: TimeUnitQuarterOfAnHour})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if query := request.Query; query.Get("timeUnit") != "QUARTER_OF_AN_HOUR" {
+		t.Errorf("got time unit %q, want QUARTER_OF_AN_HOUR", query.Get("timeUnit"))
+	}
+
+	request, err = GetMetersDataRequest(MetersDataParams{SiteId: 1, StartTime: start, EndTime: start.AddDate(0, 0, 7), Meters: []MeterType{MeterPurchased, MeterSelfConsumption, MeterProduction}})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if query := request.Query; query.Get("meters") != "Production,Purchased" || query.Get("timeUnit") != "DAY" {
+		t.Errorf("got %v", query)
+	}
+}
@@ -0,0 +1,189 @@
+package golaredge
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Validation errors returned by the Get*Request functions (and thus by the client) before any request is sent.
+// They are usually wrapped with a more specific message, use errors.Is to test for them.
+var (
+	ErrMissingAPIKey       = errors.New("please specify an api key")
+	ErrInvalidSiteID       = errors.New("site id must be an int >= 0")
+	ErrMissingSiteIDs      = errors.New("you must at least specify one site id")
+	ErrTooManySiteIDs      = errors.New("too many site ids for a single bulk request")
+	ErrMissingDates        = errors.New("both start and end date are required")
+	ErrInvalidDateRange    = errors.New("end date must be after the start date")
+	ErrDateRangeTooLarge   = errors.New("date range exceeds the limit of the endpoint")
+	ErrMissingSerialNumber = errors.New("serialNumber must be valid (not null or empty string)")
+	ErrInvalidImageSize    = errors.New("image max width and height (if specified) must be positive integers > 0")
+	ErrUnknownValue        = errors.New("unknown value")
+)
+
+// ErrIgnoredParameter is the error of the parameters the lenient validation mode drops from a request, see FieldError.
+var ErrIgnoredParameter = errors.New("parameter ignored")
+
+// ErrFutureDates is the warning of a date range starting in the future. The API accepts it but has no data for it,
+// so it is reported as a warning in both validation modes and never fails a call.
+var ErrFutureDates = errors.New("date range starts in the future")
+
+// ErrSiteNotInResponse is reported for a site that was requested in a bulk request but is not part of the response,
+// usually because the api key has no access to it.
+var ErrSiteNotInResponse = errors.New("site is missing from the bulk response")
+
+// ErrorKind classifies the errors returned by the API.
+type ErrorKind int
+
+const (
+	// ErrorKindUnknown is used for any status code that is not classified below.
+	ErrorKindUnknown ErrorKind = iota
+
+	// ErrorKindUnauthorized means the api key is missing or invalid.
+	ErrorKindUnauthorized
+
+	// ErrorKindForbidden means the api key has no access to the requested site (or the site does not exist).
+	ErrorKindForbidden
+
+	// ErrorKindQuotaExceeded means the daily limit of 300 requests was reached.
+	ErrorKindQuotaExceeded
+
+	// ErrorKindTooManyConcurrent means more than 3 requests were executed at the same time.
+	ErrorKindTooManyConcurrent
+
+	// ErrorKindBadRequest means the API rejected the parameters of the request.
+	ErrorKindBadRequest
+
+	// ErrorKindServerError means the API failed to handle the request, retrying later might help.
+	ErrorKindServerError
+)
+
+// Sentinel errors matching an APIError of the corresponding kind with errors.Is.
+var (
+	ErrUnauthorized      = errors.New("unauthorized")
+	ErrForbidden         = errors.New("forbidden")
+	ErrQuotaExceeded     = errors.New("quota exceeded")
+	ErrTooManyConcurrent = errors.New("too many concurrent requests")
+	ErrBadRequest        = errors.New("bad request")
+	ErrServerError       = errors.New("server error")
+)
+
+func (kind ErrorKind) String() string {
+	switch kind {
+	case ErrorKindUnauthorized:
+		return "Unauthorized"
+	case ErrorKindForbidden:
+		return "Forbidden"
+	case ErrorKindQuotaExceeded:
+		return "QuotaExceeded"
+	case ErrorKindTooManyConcurrent:
+		return "TooManyConcurrent"
+	case ErrorKindBadRequest:
+		return "BadRequest"
+	case ErrorKindServerError:
+		return "ServerError"
+	default:
+		return "Unknown"
+	}
+}
+
+func (kind ErrorKind) sentinel() error {
+	switch kind {
+	case ErrorKindUnauthorized:
+		return ErrUnauthorized
+	case ErrorKindForbidden:
+		return ErrForbidden
+	case ErrorKindQuotaExceeded:
+		return ErrQuotaExceeded
+	case ErrorKindTooManyConcurrent:
+		return ErrTooManyConcurrent
+	case ErrorKindBadRequest:
+		return ErrBadRequest
+	case ErrorKindServerError:
+		return ErrServerError
+	default:
+		return nil
+	}
+}
+
+// APIError is returned by the client when the API responds with a status code other than 200.
+type APIError struct {
+	StatusCode int
+
+	// Endpoint is the path of the request, e.g. "site/1/overview". It never contains the api key.
+	Endpoint string
+
+	// Message is the error message sent by SolarEdge, if any.
+	Message string
+
+	Kind ErrorKind
+
+	// RetryAfter is the wait requested by the Retry-After header of the response, 0 if there was none.
+	RetryAfter time.Duration
+}
+
+func (apiError *APIError) Error() string {
+	if apiError.Message == "" {
+		return fmt.Sprintf("solaredge %s: %d %s (%s)", apiError.Endpoint, apiError.StatusCode, http.StatusText(apiError.StatusCode), apiError.Kind)
+	}
+
+	return fmt.Sprintf("solaredge %s: %d %s (%s): %s", apiError.Endpoint, apiError.StatusCode, http.StatusText(apiError.StatusCode), apiError.Kind, apiError.Message)
+}
+
+// Is reports whether target is the sentinel error of the kind of the error, e.g. errors.Is(err, ErrQuotaExceeded).
+func (apiError *APIError) Is(target error) bool {
+	sentinel := apiError.Kind.sentinel()
+
+	return sentinel != nil && target == sentinel
+}
+
+func newAPIError(statusCode int, endpoint string, body []byte) *APIError {
+	message := errorMessage(body)
+
+	return &APIError{
+		StatusCode: statusCode,
+		Endpoint:   endpoint,
+		Message:    message,
+		Kind:       classify(statusCode, message),
+	}
+}
+
+// errorMessage extracts the message from the response body, which is either plain text or a JSON object with a single message field.
+func errorMessage(body []byte) string {
+	var fields map[string]any
+
+	if json.Unmarshal(body, &fields) == nil {
+		for _, key := range []string{"String", "message", "error"} {
+			if value, ok := fields[key].(string); ok {
+				return value
+			}
+		}
+	}
+
+	return strings.TrimSpace(string(body))
+}
+
+func classify(statusCode int, message string) ErrorKind {
+	switch {
+	case statusCode == http.StatusUnauthorized:
+		return ErrorKindUnauthorized
+	case statusCode == http.StatusForbidden:
+		return ErrorKindForbidden
+	case statusCode == http.StatusTooManyRequests:
+		// both limits are reported as 429, only the message tells them apart
+		if strings.Contains(strings.ToLower(message), "concurrent") {
+			return ErrorKindTooManyConcurrent
+		}
+
+		return ErrorKindQuotaExceeded
+	case statusCode >= 500:
+		return ErrorKindServerError
+	case statusCode >= 400:
+		return ErrorKindBadRequest
+	default:
+		return ErrorKindUnknown
+	}
+}
@@ -0,0 +1,236 @@
+package fakeapi
+
+import (
+	"math"
+	"time"
+
+	golaredge "github.com/Adrigorithm/GolarEdge"
+)
+
+const (
+	// step is the resolution of the simulation, the smallest time unit of the API.
+	step      = 15 * time.Minute
+	stepHours = 0.25
+
+	batteryCapacity       = 10000.0
+	batteryMaxPower       = 5000.0
+	batteryReserve        = 0.1
+	batteryMorningCharge  = 0.3
+	baseConsumption       = 250.0
+	morningConsumption    = 600.0
+	eveningConsumption    = 1400.0
+	pricePerKWh           = 0.25
+	co2PerKWh             = 0.5
+	kilogramsPerPound     = 0.45359237
+	treesPerKWh           = 0.017
+	lightBulbHoursPerKWh  = 0.1
+	sensorSolarIrradiance = 1000.0
+)
+
+// sample is the state of a site during a step of the simulation, in W.
+type sample struct {
+	time        time.Time
+	production  float64
+	consumption float64
+
+	// battery is positive while charging and negative while discharging.
+	battery       float64
+	stateOfCharge float64
+}
+
+// grid is the power bought from the grid, negative while feeding in.
+func (sample sample) grid() float64 {
+	return sample.consumption - sample.production + sample.battery
+}
+
+func (sample sample) feedIn() float64 {
+	return max(-sample.grid(), 0)
+}
+
+func (sample sample) purchased() float64 {
+	return max(sample.grid(), 0)
+}
+
+func (sample sample) selfConsumption() float64 {
+	return sample.production - sample.feedIn()
+}
+
+func (sample sample) meter(meter golaredge.MeterType) float64 {
+	switch meter {
+	case golaredge.MeterConsumption:
+		return sample.consumption
+	case golaredge.MeterSelfConsumption:
+		return sample.selfConsumption()
+	case golaredge.MeterFeedIn:
+		return sample.feedIn()
+	case golaredge.MeterPurchased:
+		return sample.purchased()
+	default:
+		return sample.production
+	}
+}
+
+func productionOf(sample sample) float64 {
+	return sample.production
+}
+
+// production is a sine between sunrise and sunset around 13:00, the days are longer and the peak higher in summer.
+func (site Site) production(t time.Time) float64 {
+	season := math.Cos(2 * math.Pi * float64(t.YearDay()-172) / 365)
+	dayLength := 12 + 4*season
+	sunrise := 13 - dayLength/2
+	hour := hourOfDay(t)
+
+	if hour <= sunrise || hour >= sunrise+dayLength {
+		return 0
+	}
+
+	return site.PeakPower * 1000 * (0.65 + 0.25*season) * math.Sin(math.Pi*(hour-sunrise)/dayLength)
+}
+
+// consumption is a constant base load with a peak in the morning and a larger one in the evening.
+func (site Site) consumption(t time.Time) float64 {
+	hour := hourOfDay(t)
+
+	return baseConsumption + morningConsumption*peak(hour, 7.5, 1) + eveningConsumption*peak(hour, 19, 2)
+}
+
+// simulate returns the samples of site from from until to. The battery is charged by the excess production and discharged
+// when the production does not cover the consumption, starting every day at the same state of charge.
+func (site Site) simulate(from time.Time, to time.Time) []sample {
+	samples := []sample{}
+	stateOfCharge := batteryMorningCharge
+
+	for t := startOfDay(from); t.Before(to); t = t.Add(step) {
+		if t.Hour() == 0 && t.Minute() == 0 {
+			stateOfCharge = batteryMorningCharge
+		}
+
+		current := sample{time: t, production: site.production(t), consumption: site.consumption(t)}
+
+		if site.Battery {
+			current.battery, stateOfCharge = charge(current.production-current.consumption, stateOfCharge)
+			current.stateOfCharge = stateOfCharge
+		}
+
+		if !t.Before(from) {
+			samples = append(samples, current)
+		}
+	}
+
+	return samples
+}
+
+// charge returns the power the battery charges (or discharges) with given the excess production, and its new state of charge.
+func charge(excess float64, stateOfCharge float64) (float64, float64) {
+	power := 0.0
+
+	if excess > 0 {
+		power = min(excess, batteryMaxPower, (1-stateOfCharge)*batteryCapacity/stepHours)
+	} else {
+		power = -min(-excess, batteryMaxPower, max(stateOfCharge-batteryReserve, 0)*batteryCapacity/stepHours)
+	}
+
+	return power, stateOfCharge + power*stepHours/batteryCapacity
+}
+
+// series aggregates value over the periods of timeUnit between from and to, either as the energy (Wh) or the average power (W).
+// Periods that did not start yet have no value.
+func series(samples []sample, from time.Time, to time.Time, now time.Time, timeUnit golaredge.TimeUnit, value func(sample sample) float64, average bool) []golaredge.DateValue {
+	values := []golaredge.DateValue{}
+	i := 0
+
+	for start := periodStart(from, timeUnit); start.Before(to); start = nextPeriod(start, timeUnit) {
+		if start.After(now) {
+			values = append(values, golaredge.DateValue{Date: golaredge.Timestamp{Time: start}})
+
+			continue
+		}
+
+		end := nextPeriod(start, timeUnit)
+		total := 0.0
+		count := 0
+
+		for ; i < len(samples) && samples[i].time.Before(end); i++ {
+			total += value(samples[i])
+			count++
+		}
+
+		if average && count > 0 {
+			total /= float64(count)
+		} else {
+			total *= stepHours
+		}
+
+		total = round(total)
+		values = append(values, golaredge.DateValue{Date: golaredge.Timestamp{Time: start}, Value: &total})
+	}
+
+	return values
+}
+
+// energy returns the energy (Wh) of value from from until to.
+func energy(samples []sample, value func(sample sample) float64) float64 {
+	total := 0.0
+
+	for i := range samples {
+		total += value(samples[i])
+	}
+
+	return round(total * stepHours)
+}
+
+func periodStart(t time.Time, timeUnit golaredge.TimeUnit) time.Time {
+	year, month, day := t.Date()
+
+	switch timeUnit {
+	case golaredge.TimeUnitQuarterOfAnHour:
+		return time.Date(year, month, day, t.Hour(), t.Minute()/15*15, 0, 0, t.Location())
+	case golaredge.TimeUnitHour:
+		return time.Date(year, month, day, t.Hour(), 0, 0, 0, t.Location())
+	case golaredge.TimeUnitWeek:
+		return time.Date(year, month, day-(int(t.Weekday())+6)%7, 0, 0, 0, 0, t.Location())
+	case golaredge.TimeUnitMonth:
+		return time.Date(year, month, 1, 0, 0, 0, 0, t.Location())
+	case golaredge.TimeUnitYear:
+		return time.Date(year, time.January, 1, 0, 0, 0, 0, t.Location())
+	default:
+		return startOfDay(t)
+	}
+}
+
+func nextPeriod(start time.Time, timeUnit golaredge.TimeUnit) time.Time {
+	switch timeUnit {
+	case golaredge.TimeUnitQuarterOfAnHour:
+		return start.Add(step)
+	case golaredge.TimeUnitHour:
+		return start.Add(time.Hour)
+	case golaredge.TimeUnitWeek:
+		return start.AddDate(0, 0, 7)
+	case golaredge.TimeUnitMonth:
+		return start.AddDate(0, 1, 0)
+	case golaredge.TimeUnitYear:
+		return start.AddDate(1, 0, 0)
+	default:
+		return start.AddDate(0, 0, 1)
+	}
+}
+
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+func hourOfDay(t time.Time) float64 {
+	return float64(t.Hour()) + float64(t.Minute())/60 + float64(t.Second())/3600
+}
+
+// peak is a bell curve of width hours around center.
+func peak(hour float64, center float64, width float64) float64 {
+	return math.Exp(-math.Pow((hour-center)/width, 2))
+}
+
+func round(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -0,0 +1,808 @@
+package fakeapi
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	golaredge "github.com/Adrigorithm/GolarEdge"
+)
+
+const (
+	dateLayout     = "2006-01-02"
+	dateTimeLayout = "2006-01-02 15:04:05"
+)
+
+// call is a request being answered with the sites of its path, or every site the api key can access if it has none.
+type call struct {
+	request golaredge.Request
+	sites   []Site
+	now     time.Time
+}
+
+// handler returns the response of a call, []byte for an image. An error is answered as a bad request.
+type handler func(call call) (any, error)
+
+var handlers = map[golaredge.Endpoint]handler{
+	// Site Data API
+	golaredge.EndpointSiteList:                  siteList,
+	golaredge.EndpointSiteDetails:               siteDetails,
+	golaredge.EndpointSiteDataPeriod:            siteDataPeriod,
+	golaredge.EndpointSiteDataPeriodBulk:        siteDataPeriodBulk,
+	golaredge.EndpointSiteEnergy:                siteEnergy,
+	golaredge.EndpointSiteEnergyBulk:            siteEnergyBulk,
+	golaredge.EndpointSiteTimeFrameEnergy:       siteTimeFrameEnergy,
+	golaredge.EndpointSiteTimeFrameEnergyBulk:   siteTimeFrameEnergyBulk,
+	golaredge.EndpointSitePower:                 sitePower,
+	golaredge.EndpointSitePowerBulk:             sitePowerBulk,
+	golaredge.EndpointSiteOverview:              siteOverview,
+	golaredge.EndpointSiteOverviewBulk:          siteOverviewBulk,
+	golaredge.EndpointSitePowerDetails:          sitePowerDetails,
+	golaredge.EndpointSiteEnergyDetails:         siteEnergyDetails,
+	golaredge.EndpointSiteCurrentPowerFlow:      sitePowerFlow,
+	golaredge.EndpointSiteStorageData:           storageData,
+	golaredge.EndpointSiteImage:                 siteImage,
+	golaredge.EndpointSiteImageName:             siteImage,
+	golaredge.EndpointSiteEnvironmentalBenefits: environmentalBenefits,
+	golaredge.EndpointSiteInstallerImage:        installerImage,
+	golaredge.EndpointSiteInstallerImageName:    installerImage,
+
+	// Site Equipment API
+	golaredge.EndpointComponentsList:        componentsList,
+	golaredge.EndpointSiteInventory:         inventory,
+	golaredge.EndpointInverterTechnicalData: inverterTechnicalData,
+	golaredge.EndpointEquipmentChangeLog:    equipmentChangeLog,
+
+	// Account List API
+	golaredge.EndpointAccountList: accountList,
+
+	// Meters API
+	golaredge.EndpointSiteMeters: metersData,
+
+	// Sensors API
+	golaredge.EndpointSensorsList: sensorsList,
+	golaredge.EndpointSiteSensors: sensorData,
+
+	// API Versions
+	golaredge.EndpointCurrentVersion:   currentVersion,
+	golaredge.EndpointSupportedVersion: supportedVersion,
+}
+
+// Site Data API
+
+func siteList(call call) (any, error) {
+	sites := filterByName(call.sites, call.request.Query.Get("searchText"), func(site Site) string { return site.Name })
+
+	slices.SortFunc(sites, func(a Site, b Site) int {
+		switch call.request.Query.Get("sortProperty") {
+		case "Name":
+			return strings.Compare(a.Name, b.Name)
+		case "PeakPower":
+			return int(math.Copysign(1, a.PeakPower-b.PeakPower))
+		case "InstallationDate":
+			return a.InstallationDate.Compare(b.InstallationDate)
+		default:
+			return a.Id - b.Id
+		}
+	})
+
+	if call.request.Query.Get("sortOrder") == "DESC" {
+		slices.Reverse(sites)
+	}
+
+	page, err := paginate(sites, call)
+
+	if err != nil {
+		return nil, err
+	}
+
+	response := golaredge.SiteListResponse{Sites: golaredge.SiteList{Count: len(sites), Site: []golaredge.Site{}}}
+
+	for _, site := range page {
+		response.Sites.Site = append(response.Sites.Site, site.details(call.now))
+	}
+
+	return response, nil
+}
+
+func siteDetails(call call) (any, error) {
+	return golaredge.SiteDetailsResponse{Details: call.sites[0].details(call.now)}, nil
+}
+
+func siteDataPeriod(call call) (any, error) {
+	return golaredge.SiteDataPeriodResponse{DataPeriod: call.sites[0].dataPeriod(call.now)}, nil
+}
+
+func siteDataPeriodBulk(call call) (any, error) {
+	list := golaredge.SitesDataPeriod{Count: len(call.sites), SiteEnergyList: []golaredge.DataPeriod1{}}
+
+	for _, site := range call.sites {
+		period := site.dataPeriod(call.now)
+		list.SiteEnergyList = append(list.SiteEnergyList, golaredge.DataPeriod1{Id: site.Id, StartDate: period.StartDate, EndDate: period.EndDate})
+	}
+
+	return golaredge.SiteDataPeriodBulkResponse{DataPeriodList: list}, nil
+}
+
+func siteEnergy(call call) (any, error) {
+	timeUnit, err := call.timeUnit()
+
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := call.sites[0].energy(call, timeUnit)
+
+	return golaredge.SiteEnergyResponse{Energy: golaredge.SiteEnergy{
+		TimeUnit:   timeUnit.String(),
+		Unit:       "Wh",
+		MeasuredBy: values.MeasuredBy,
+		Values:     values.Values,
+	}}, err
+}
+
+func siteEnergyBulk(call call) (any, error) {
+	timeUnit, err := call.timeUnit()
+
+	if err != nil {
+		return nil, err
+	}
+
+	list := golaredge.SitesEnergy{TimeUnit: timeUnit.String(), Unit: "Wh", Count: len(call.sites), SiteEnergyList: []golaredge.SiteEnergyValues{}}
+
+	for _, site := range call.sites {
+		values, err := site.energy(call, timeUnit)
+
+		if err != nil {
+			return nil, err
+		}
+
+		list.SiteEnergyList = append(list.SiteEnergyList, golaredge.SiteEnergyValues{SiteId: site.Id, EnergyValues: values})
+	}
+
+	return golaredge.SiteEnergyBulkResponse{SitesEnergy: list}, nil
+}
+
+func siteTimeFrameEnergy(call call) (any, error) {
+	timeFrame, err := call.sites[0].timeFrameEnergy(call)
+
+	return golaredge.SiteEnergyTimePeriodResponse{TimeFrameEnergy: timeFrame}, err
+}
+
+func siteTimeFrameEnergyBulk(call call) (any, error) {
+	list := golaredge.SitesEnergyTimePeriod{Count: len(call.sites), TimeFrameEnergyList: []golaredge.SiteEnergyTimePeriodValues{}}
+
+	for _, site := range call.sites {
+		timeFrame, err := site.timeFrameEnergy(call)
+
+		if err != nil {
+			return nil, err
+		}
+
+		list.TimeFrameEnergyList = append(list.TimeFrameEnergyList, golaredge.SiteEnergyTimePeriodValues{SiteId: site.Id, TimeFrameEnergy: timeFrame})
+	}
+
+	return golaredge.SiteEnergyTimePeriodBulkResponse{TimeFrameEnergyList: list}, nil
+}
+
+func sitePower(call call) (any, error) {
+	values, err := call.sites[0].power(call)
+
+	return golaredge.SitePowerResponse{Power: golaredge.SitePower{
+		TimeUnit:   golaredge.TimeUnitQuarterOfAnHour.String(),
+		Unit:       "W",
+		MeasuredBy: values.MeasuredBy,
+		Values:     values.Values,
+	}}, err
+}
+
+func sitePowerBulk(call call) (any, error) {
+	list := golaredge.SitesPower{TimeUnit: golaredge.TimeUnitQuarterOfAnHour.String(), Unit: "W", Count: len(call.sites), SiteEnergyList: []golaredge.SitePowerValues{}}
+
+	for _, site := range call.sites {
+		values, err := site.power(call)
+
+		if err != nil {
+			return nil, err
+		}
+
+		list.SiteEnergyList = append(list.SiteEnergyList, golaredge.SitePowerValues{SiteId: site.Id, PowerDataValueSeries: values})
+	}
+
+	return golaredge.SitePowerBulkResponse{PowerDateValuesList: list}, nil
+}
+
+func siteOverview(call call) (any, error) {
+	return golaredge.SiteOverviewResponse{Overview: call.sites[0].overview(call.now)}, nil
+}
+
+func siteOverviewBulk(call call) (any, error) {
+	list := golaredge.SitesOverview{Count: len(call.sites), SiteEnergyList: []golaredge.SiteOverviewValues{}}
+
+	for _, site := range call.sites {
+		list.SiteEnergyList = append(list.SiteEnergyList, golaredge.SiteOverviewValues{SiteId: site.Id, SiteOverview: site.overview(call.now)})
+	}
+
+	return golaredge.SiteOverviewBulkResponse{SitesOverviews: list}, nil
+}
+
+func sitePowerDetails(call call) (any, error) {
+	meters, err := call.sites[0].detailedMeters(call, golaredge.TimeUnitQuarterOfAnHour, true)
+	details := golaredge.SitePowerDetailed{TimeUnit: golaredge.TimeUnitQuarterOfAnHour.String(), Unit: "W", Meters: meters}
+
+	return golaredge.SitePowerDetailedResponse{PowerDetails: details}, err
+}
+
+func siteEnergyDetails(call call) (any, error) {
+	timeUnit, err := call.timeUnit()
+
+	if err != nil {
+		return nil, err
+	}
+
+	meters, err := call.sites[0].detailedMeters(call, timeUnit, false)
+	details := golaredge.SiteEnergyDetailed{TimeUnit: timeUnit.String(), Unit: "Wh", Meters: meters}
+
+	return golaredge.SiteEnergyDetailedResponse{EnergyDetails: details}, err
+}
+
+func sitePowerFlow(call call) (any, error) {
+	site := call.sites[0]
+	now := call.now.In(site.location)
+	samples := site.simulate(startOfDay(now), now.Add(time.Nanosecond))
+	current := samples[len(samples)-1]
+
+	flow := golaredge.SitePowerFlow{
+		UpdateRefreshRate: 3,
+		Unit:              "kW",
+		Connections:       []golaredge.PowerFlowConnection{},
+		PV:                flowElement(current.production),
+		Load:              flowElement(current.consumption),
+		Grid:              flowElement(current.grid()),
+	}
+
+	connect := func(condition bool, from string, to string) {
+		if condition {
+			flow.Connections = append(flow.Connections, golaredge.PowerFlowConnection{From: from, To: to})
+		}
+	}
+
+	connect(current.production > 0, "PV", "Load")
+	connect(current.battery > 0, "PV", "Storage")
+	connect(current.battery < 0, "Storage", "Load")
+	connect(current.grid() > 0, "GRID", "Load")
+	connect(current.grid() < 0, "Load", "GRID")
+
+	if site.Battery {
+		chargeLevel := math.Round(current.stateOfCharge * 100)
+		critical := current.stateOfCharge <= batteryReserve
+		flow.Storage = flowElement(current.battery)
+		flow.Storage.ChargeLevel = &chargeLevel
+		flow.Storage.Critical = &critical
+
+		switch {
+		case current.battery > 0:
+			flow.Storage.Status = "Charging"
+		case current.battery < 0:
+			flow.Storage.Status = "Discharging"
+		}
+	}
+
+	return golaredge.SitePowerFlowResponse{SiteCurrentPowerFlow: flow}, nil
+}
+
+func storageData(call call) (any, error) {
+	site := call.sites[0]
+	from, to, err := call.timeRange(site, "startTime", "endTime")
+	storage := golaredge.StorageInformation{Batteries: []golaredge.Battery{}}
+
+	if err != nil || !site.Battery {
+		return golaredge.StorageInformationResponse{StorageData: storage}, err
+	}
+
+	if serials := call.request.Query.Get("serials"); serials != "" && !slices.Contains(strings.Split(serials, ","), site.serial("BAT")) {
+		return golaredge.StorageInformationResponse{StorageData: storage}, nil
+	}
+
+	battery := golaredge.Battery{Nameplate: batteryCapacity, SerialNumber: site.serial("BAT"), ModelNumber: "BAT-10K1P", Telemetries: []golaredge.BatteryTelemetry{}}
+	charged, discharged := 0.0, 0.0
+
+	for _, sample := range site.simulate(site.InstallationDate, earliest(to, call.now)) {
+		charged += max(sample.battery, 0) * stepHours
+		discharged += max(-sample.battery, 0) * stepHours
+
+		if sample.time.Before(from) {
+			continue
+		}
+
+		power := round(sample.battery)
+		stateOfCharge := round(sample.stateOfCharge * 100)
+
+		battery.Telemetries = append(battery.Telemetries, golaredge.BatteryTelemetry{
+			TimeStamp:                golaredge.Timestamp{Time: sample.time},
+			Power:                    &power,
+			BatteryState:             3,
+			LifeTimeEnergyCharged:    round(charged),
+			LifeTimeEnergyDischarged: round(discharged),
+			FullPackEnergyAvailable:  batteryCapacity,
+			InternalTemp:             25,
+			StateOfCharge:            &stateOfCharge,
+		})
+	}
+
+	battery.TelemetryCount = len(battery.Telemetries)
+	storage.BatteryCount = 1
+	storage.Batteries = append(storage.Batteries, battery)
+
+	return golaredge.StorageInformationResponse{StorageData: storage}, nil
+}
+
+func siteImage(call call) (any, error) {
+	width, err := call.intParam("maxWidth", 400)
+
+	if err != nil {
+		return nil, err
+	}
+
+	height, err := call.intParam("maxHeight", 300)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return encodeImage(width, height, color.RGBA{R: 40, G: 90, B: 160, A: 255})
+}
+
+func installerImage(call call) (any, error) {
+	return encodeImage(200, 100, color.RGBA{R: 230, G: 30, B: 40, A: 255})
+}
+
+func environmentalBenefits(call call) (any, error) {
+	site := call.sites[0]
+	produced := energy(site.simulate(site.InstallationDate, call.now), productionOf) / 1000
+	emissions := golaredge.GasEmissionSaved{Units: "kg", CO2: round(produced * co2PerKWh), SO2: round(produced * co2PerKWh / 1000), NOX: round(produced * co2PerKWh / 2000)}
+
+	if call.request.Query.Get("systemUnits") == golaredge.SystemUnitsImperial.String() {
+		emissions = golaredge.GasEmissionSaved{Units: "lb", CO2: round(emissions.CO2 / kilogramsPerPound), SO2: round(emissions.SO2 / kilogramsPerPound), NOX: round(emissions.NOX / kilogramsPerPound)}
+	}
+
+	return golaredge.SiteEnvironmentalBenefitsResponse{EnvBenefits: golaredge.SiteEnvironmentalBenefits{
+		GasEmissionSaved: emissions,
+		TreesPlanted:     round(produced * treesPerKWh),
+		LightBulbs:       round(produced * lightBulbHoursPerKWh),
+	}}, nil
+}
+
+// Site Equipment API
+
+func componentsList(call call) (any, error) {
+	site := call.sites[0]
+	inverter := golaredge.Component{Name: "Inverter 1", Manufacturer: "SolarEdge", Model: "SE5K-RW0TEBEN4", SerialNumber: site.serial("INV")}
+
+	return golaredge.ComponentsListResponse{Reporters: golaredge.ComponentsList{Count: 1, List: []golaredge.Component{inverter}}}, nil
+}
+
+func inventory(call call) (any, error) {
+	site := call.sites[0]
+	inventory := golaredge.Inventory{
+		Meters:    []golaredge.InventoryMeter{},
+		Sensors:   []golaredge.InventorySensor{},
+		Gateways:  []golaredge.InventoryGateway{},
+		Batteries: []golaredge.InventoryBattery{},
+		Inverters: []golaredge.InventoryInverter{{
+			Name:                "Inverter 1",
+			Manufacturer:        "SolarEdge",
+			Model:               "SE5K-RW0TEBEN4",
+			CommunicationMethod: "ETHERNET",
+			CpuVersion:          "4.18.51",
+			SerialNumber:        site.serial("INV"),
+			ConnectedOptimizers: int(site.PeakPower * 1000 / 400),
+		}},
+	}
+
+	for _, meter := range site.meters() {
+		inventory.Meters = append(inventory.Meters, golaredge.InventoryMeter{
+			Name:                       meter.String() + " Meter",
+			Manufacturer:               "SolarEdge",
+			Model:                      "SE-MTR-3Y-400V-A",
+			FirmwareVersion:            "3.31",
+			ConnectedSolaredgeDeviceSN: site.serial("INV"),
+			Type:                       meter.String(),
+			Form:                       "physical",
+		})
+	}
+
+	if site.Battery {
+		inventory.Batteries = append(inventory.Batteries, golaredge.InventoryBattery{
+			Name:                "Battery 1",
+			Manufacturer:        "SolarEdge",
+			Model:               "BAT-10K1P",
+			FirmwareVersion:     "2.0.19",
+			ConnectedInverterSn: site.serial("INV"),
+			NameplateCapacity:   batteryCapacity,
+			SerialNumber:        site.serial("BAT"),
+		})
+	}
+
+	if site.Sensors {
+		inventory.Gateways = append(inventory.Gateways, golaredge.InventoryGateway{Name: "Gateway 1", SerialNumber: site.serial("GW"), FirmwareVersion: "2.1.9"})
+
+		for _, sensor := range sensors {
+			inventory.Sensors = append(inventory.Sensors, golaredge.InventorySensor{
+				ConnectedSolaredgeDeviceSN: site.serial("GW"),
+				Id:                         sensor.Name,
+				ConnectedTo:                "Gateway 1",
+				Category:                   sensor.Type,
+				Type:                       sensor.Measurement,
+			})
+		}
+	}
+
+	return golaredge.InventoryResponse{Inventory: inventory}, nil
+}
+
+func inverterTechnicalData(call call) (any, error) {
+	site := call.sites[0]
+
+	if call.request.PathParams["serialNumber"] != site.serial("INV") {
+		return nil, errors.New("Invalid serial number")
+	}
+
+	from, to, err := call.timeRange(site, "startTime", "endTime")
+
+	if err != nil {
+		return nil, err
+	}
+
+	data := golaredge.InverterTechnicalData{Telemetries: []golaredge.InverterTelemetry{}}
+	total := 0.0
+
+	for _, sample := range site.simulate(site.InstallationDate, earliest(to, call.now)) {
+		total += sample.production * stepHours
+
+		if sample.time.Before(from) {
+			continue
+		}
+
+		telemetry := golaredge.InverterTelemetry{
+			Date:         golaredge.Timestamp{Time: sample.time},
+			PowerLimit:   100,
+			TotalEnergy:  round(total),
+			Temperature:  round(20 + sample.production/(site.PeakPower*1000)*25),
+			InverterMode: "SLEEPING",
+			L1Data:       golaredge.InverterPhaseData{AcVoltage: 230, AcFrequency: 50},
+		}
+
+		if sample.production > 0 {
+			telemetry.InverterMode = "MPPT"
+			telemetry.OperationMode = 0
+			telemetry.TotalActivePower = round(sample.production)
+			telemetry.DcVoltage = 750
+			telemetry.GroundFaultResistance = 5000
+			telemetry.L1Data.AcCurrent = round(sample.production / 230)
+			telemetry.L1Data.ApparentPower = round(sample.production)
+			telemetry.L1Data.ActivePower = round(sample.production)
+			telemetry.L1Data.CosPhi = 1
+		}
+
+		data.Telemetries = append(data.Telemetries, telemetry)
+	}
+
+	data.Count = len(data.Telemetries)
+
+	return golaredge.InverterTechnicalDataResponse{Data: data}, nil
+}
+
+func equipmentChangeLog(call call) (any, error) {
+	site := call.sites[0]
+
+	if call.request.PathParams["serialNumber"] != site.serial("INV") {
+		return nil, errors.New("Invalid serial number")
+	}
+
+	change := golaredge.EquipmentChange{SerialNumber: site.serial("INV"), PartNumber: "SE5K-RW0TEBEN4", Date: golaredge.Timestamp{Time: startOfDay(site.InstallationDate)}}
+
+	return golaredge.EquipmentChangeLogResponse{ChangeLog: golaredge.EquipmentChangeLog{Count: 1, List: []golaredge.EquipmentChange{change}}}, nil
+}
+
+// Account List API
+
+func accountList(call call) (any, error) {
+	accounts := []golaredge.Account{}
+
+	for _, site := range call.sites {
+		if !slices.ContainsFunc(accounts, func(account golaredge.Account) bool { return account.Id == site.AccountId }) {
+			accounts = append(accounts, golaredge.Account{
+				Id:            site.AccountId,
+				Name:          fmt.Sprintf("Fake account %d", site.AccountId),
+				Location:      site.locationDetails(),
+				ContactPerson: "Jane Doe",
+				Email:         fmt.Sprintf("account%d@example.com", site.AccountId),
+			})
+		}
+	}
+
+	accounts = filterByName(accounts, call.request.Query.Get("searchText"), func(account golaredge.Account) string { return account.Name })
+
+	if call.request.Query.Get("sortOrder") == "DESC" {
+		slices.Reverse(accounts)
+	}
+
+	page, err := paginate(accounts, call)
+
+	return golaredge.AccountListResponse{Accounts: golaredge.AccountList{Count: len(accounts), List: page}}, err
+}
+
+// Meters API
+
+func metersData(call call) (any, error) {
+	site := call.sites[0]
+	timeUnit, err := call.timeUnit()
+
+	if err != nil {
+		return nil, err
+	}
+
+	from, to, err := call.timeRange(site, "startTime", "endTime")
+
+	if err != nil {
+		return nil, err
+	}
+
+	selected, err := call.meters(site)
+
+	if err != nil {
+		return nil, err
+	}
+
+	samples := site.simulate(site.InstallationDate, earliest(to, call.now))
+	start, _ := slices.BinarySearchFunc(samples, from, func(sample sample, from time.Time) int { return sample.time.Compare(from) })
+	data := golaredge.MetersData{TimeUnit: timeUnit.String(), Unit: "Wh", Meters: []golaredge.MeterEnergy{}}
+
+	for _, meter := range selected {
+		// self consumption is not measured by a meter
+		if meter == golaredge.MeterSelfConsumption {
+			continue
+		}
+
+		value := func(sample sample) float64 { return sample.meter(meter) }
+		reading := energy(samples[:start], value)
+		values := series(samples[start:], from, to, call.now, timeUnit, value, false)
+
+		for i := range values {
+			if values[i].Value != nil {
+				reading += *values[i].Value
+				*values[i].Value = round(reading)
+			}
+		}
+
+		data.Meters = append(data.Meters, golaredge.MeterEnergy{
+			MeterSerialNumber:          site.serial("MTR" + strconv.Itoa(int(meter))),
+			ConnectedSolaredgeDeviceSN: site.serial("INV"),
+			Model:                      "SE-MTR-3Y-400V-A",
+			MeterType:                  meter.String(),
+			Values:                     values,
+		})
+	}
+
+	return golaredge.MetersDataResponse{MeterEnergyDetails: data}, nil
+}
+
+// Sensors API
+
+var sensors = []golaredge.Sensor{
+	{Name: "Irradiance", Measurement: "GlobalHorizontalIrradiance", Type: "IRRADIANCE"},
+	{Name: "Ambient temperature", Measurement: "AmbientTemperature", Type: "TEMPERATURE"},
+	{Name: "Module temperature", Measurement: "ModuleTemperature", Type: "TEMPERATURE"},
+	{Name: "Wind speed", Measurement: "WindSpeed", Type: "WIND"},
+}
+
+func sensorsList(call call) (any, error) {
+	list := golaredge.SensorsList{List: []golaredge.SensorGateway{}}
+
+	if call.sites[0].Sensors {
+		list.Count = 1
+		list.List = append(list.List, golaredge.SensorGateway{ConnectedTo: "Gateway 1", Count: len(sensors), Sensors: sensors})
+	}
+
+	return golaredge.SensorsListResponse{SiteSensors: list}, nil
+}
+
+func sensorData(call call) (any, error) {
+	site := call.sites[0]
+	from, to, err := call.timeRange(site, "startDate", "endDate")
+	data := golaredge.SensorData{Data: []golaredge.SensorGatewayData{}}
+
+	if err != nil || !site.Sensors {
+		return golaredge.SensorDataResponse{SiteSensors: data}, err
+	}
+
+	gateway := golaredge.SensorGatewayData{ConnectedTo: "Gateway 1", Telemetries: []golaredge.SensorTelemetry{}}
+
+	for _, sample := range site.simulate(from, earliest(to, call.now)) {
+		irradiance := round(sample.production / (site.PeakPower * 1000) * sensorSolarIrradiance)
+		season := math.Cos(2 * math.Pi * float64(sample.time.YearDay()-172) / 365)
+		ambient := round(10 + 8*season + 5*peak(hourOfDay(sample.time), 15, 4))
+		module := round(ambient + irradiance*0.03)
+		wind := round(3 + 2*math.Sin(2*math.Pi*hourOfDay(sample.time)/24))
+
+		gateway.Telemetries = append(gateway.Telemetries, golaredge.SensorTelemetry{
+			Date:                       golaredge.Timestamp{Time: sample.time},
+			GlobalHorizontalIrradiance: &irradiance,
+			AmbientTemperature:         &ambient,
+			ModuleTemperature:          &module,
+			WindSpeed:                  &wind,
+		})
+	}
+
+	gateway.Count = len(gateway.Telemetries)
+	data.Data = append(data.Data, gateway)
+
+	return golaredge.SensorDataResponse{SiteSensors: data}, nil
+}
+
+// API Versions
+
+func currentVersion(call call) (any, error) {
+	return golaredge.CurrentVersionResponse{Version: golaredge.Version{Release: "1.0.0"}}, nil
+}
+
+func supportedVersion(call call) (any, error) {
+	return golaredge.SupportedVersionResponse{Supported: []golaredge.Version{{Release: "1.0.0"}}}, nil
+}
+
+// parameters
+
+// timeRange returns the range of the startKey and endKey parameters in the time zone of site.
+// An end date without time includes that day.
+func (call call) timeRange(site Site, startKey string, endKey string) (time.Time, time.Time, error) {
+	from, _, err := parseTime(call.request.Query.Get(startKey), startKey, site.location)
+
+	if err != nil {
+		return from, from, err
+	}
+
+	to, dateOnly, err := parseTime(call.request.Query.Get(endKey), endKey, site.location)
+
+	if dateOnly {
+		to = to.AddDate(0, 0, 1)
+	}
+
+	if err == nil && !from.Before(to) {
+		err = fmt.Errorf("%s must be after %s", endKey, startKey)
+	}
+
+	return from, to, err
+}
+
+func parseTime(value string, key string, location *time.Location) (time.Time, bool, error) {
+	if value == "" {
+		return time.Time{}, false, fmt.Errorf("%s is required", key)
+	}
+
+	if parsed, err := time.ParseInLocation(dateTimeLayout, value, location); err == nil {
+		return parsed, false, nil
+	}
+
+	parsed, err := time.ParseInLocation(dateLayout, value, location)
+
+	if err != nil {
+		return parsed, false, fmt.Errorf("invalid %s %q", key, value)
+	}
+
+	return parsed, true, nil
+}
+
+func (call call) timeUnit() (golaredge.TimeUnit, error) {
+	if value := call.request.Query.Get("timeUnit"); value != "" {
+		return golaredge.ParseTimeUnit(value)
+	}
+
+	return golaredge.TimeUnitDay, nil
+}
+
+// meters returns the meters of the meters parameter the site has, all its meters if the parameter is missing.
+func (call call) meters(site Site) ([]golaredge.MeterType, error) {
+	value := call.request.Query.Get("meters")
+
+	if value == "" {
+		return site.meters(), nil
+	}
+
+	meters := []golaredge.MeterType{}
+
+	for _, name := range strings.Split(value, ",") {
+		meter, err := golaredge.ParseMeterType(name)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if slices.Contains(site.meters(), meter) {
+			meters = append(meters, meter)
+		}
+	}
+
+	return meters, nil
+}
+
+func (call call) intParam(key string, fallback int) (int, error) {
+	value := call.request.Query.Get(key)
+
+	if value == "" {
+		return fallback, nil
+	}
+
+	parsed, err := strconv.Atoi(value)
+
+	if err != nil || parsed <= 0 {
+		return 0, fmt.Errorf("invalid %s %q", key, value)
+	}
+
+	return parsed, nil
+}
+
+// paginate returns the page of the size and startIndex parameters.
+func paginate[T any](items []T, call call) ([]T, error) {
+	size, err := call.intParam("size", 100)
+
+	if err != nil {
+		return nil, err
+	}
+
+	startIndex := 0
+
+	if value := call.request.Query.Get("startIndex"); value != "" {
+		if startIndex, err = strconv.Atoi(value); err != nil || startIndex < 0 {
+			return nil, fmt.Errorf("invalid startIndex %q", value)
+		}
+	}
+
+	startIndex = min(startIndex, len(items))
+
+	return items[startIndex:min(startIndex+size, len(items))], nil
+}
+
+func filterByName[T any](items []T, searchText string, name func(item T) string) []T {
+	return slices.DeleteFunc(slices.Clone(items), func(item T) bool {
+		return !strings.Contains(strings.ToLower(name(item)), strings.ToLower(searchText))
+	})
+}
+
+func encodeImage(width int, height int, fill color.Color) ([]byte, error) {
+	canvas := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(canvas, canvas.Bounds(), image.NewUniform(fill), image.Point{}, draw.Src)
+
+	buffer := bytes.Buffer{}
+	err := png.Encode(&buffer, canvas)
+
+	return buffer.Bytes(), err
+}
+
+func earliest(a time.Time, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+
+	return b
+}
+
+// flowElement returns the element of a power flow for power in W.
+func flowElement(power float64) *golaredge.PowerFlowElement {
+	element := &golaredge.PowerFlowElement{Status: "Active", CurrentPower: round(math.Abs(power) / 1000)}
+
+	if element.CurrentPower == 0 {
+		element.Status = "Idle"
+	}
+
+	return element
+}
@@ -0,0 +1,324 @@
+// Package fakeapi provides an in-process fake of the SolarEdge Monitoring API serving deterministic synthetic data,
+// so the client can be tested end to end without access to monitoringapi.solaredge.com.
+package fakeapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	golaredge "github.com/Adrigorithm/GolarEdge"
+)
+
+// Site is a site served by the fake server. Its production follows the sun of the northern hemisphere in its time zone.
+type Site struct {
+	Id        int
+	Name      string
+	AccountId int
+
+	// PeakPower is the installed power in kWp.
+	PeakPower float64
+
+	// TimeZone is the IANA time zone of the site, UTC if empty.
+	TimeZone string
+
+	// InstallationDate is the start of the data period.
+	InstallationDate time.Time
+
+	// Battery adds a battery charged by the excess production.
+	Battery bool
+
+	// Meters adds consumption, feed in and purchase meters, otherwise only the production of the inverter is measured.
+	Meters bool
+
+	// Sensors adds an irradiance, temperature and wind sensor gateway.
+	Sensors bool
+
+	location *time.Location
+}
+
+// Config configures a Server. Zero values are replaced by the limits documented by SolarEdge.
+type Config struct {
+	Sites []Site
+
+	// APIKeys maps every api key the server accepts to the ids of the sites it can access, nil for every site.
+	APIKeys map[string][]int
+
+	// DailyLimit is the number of requests allowed per api key and per day (default 300).
+	DailyLimit int
+
+	// MaxConcurrent is the number of requests the server handles at the same time (default 3).
+	MaxConcurrent int
+
+	// Latency delays every response, e.g. to run into the concurrency limit.
+	Latency time.Duration
+
+	// Now returns the current time (default time.Now), data after it is not available yet.
+	Now func() time.Time
+}
+
+// DefaultConfig returns a config with a single site with every feature, accessible with the api key "test-key".
+func DefaultConfig() Config {
+	return Config{
+		Sites: []Site{{
+			Id:               1,
+			Name:             "Fake site",
+			AccountId:        1,
+			PeakPower:        6.5,
+			TimeZone:         "Europe/Brussels",
+			InstallationDate: time.Date(2022, 3, 15, 0, 0, 0, 0, time.UTC),
+			Battery:          true,
+			Meters:           true,
+			Sensors:          true,
+		}},
+		APIKeys: map[string][]int{"test-key": nil},
+	}
+}
+
+// failure is an error response injected with Server.Fail.
+type failure struct {
+	statusCode int
+	message    string
+	remaining  int
+}
+
+// Server is a fake of the Monitoring API listening on a random local port, see Server.URL.
+// It enforces the api keys, site permissions and limits of its config like the API does.
+type Server struct {
+	server *httptest.Server
+	config Config
+	sites  map[int]Site
+
+	mutex    sync.Mutex
+	day      string
+	active   int
+	usage    map[string]int
+	failures map[golaredge.Endpoint]*failure
+	requests []golaredge.Request
+}
+
+// NewServer starts a server serving the sites of config.
+func NewServer(config Config) (*Server, error) {
+	if config.DailyLimit <= 0 {
+		config.DailyLimit = golaredge.DefaultDailyLimit
+	}
+
+	if config.MaxConcurrent <= 0 {
+		config.MaxConcurrent = golaredge.DefaultMaxConcurrent
+	}
+
+	if config.Now == nil {
+		config.Now = time.Now
+	}
+
+	server := &Server{config: config, sites: map[int]Site{}, usage: map[string]int{}, failures: map[golaredge.Endpoint]*failure{}}
+
+	for _, site := range config.Sites {
+		location, err := time.LoadLocation(site.TimeZone)
+
+		if err != nil {
+			return nil, fmt.Errorf("site %d: %w", site.Id, err)
+		}
+
+		site.location = location
+		site.InstallationDate = site.InstallationDate.In(location)
+		server.sites[site.Id] = site
+	}
+
+	server.server = httptest.NewServer(http.HandlerFunc(server.serveHTTP))
+
+	return server, nil
+}
+
+// URL returns the base url of the server, to pass to golaredge.WithBaseURL.
+func (server *Server) URL() string {
+	return server.server.URL
+}
+
+// Close stops the server.
+func (server *Server) Close() {
+	server.server.Close()
+}
+
+// Usage returns the number of requests apiKey executed today, including the rejected ones.
+func (server *Server) Usage(apiKey string) int {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	server.resetIfNewDay()
+
+	return server.usage[apiKey]
+}
+
+// Requests returns the requests the server received, in order.
+func (server *Server) Requests() []golaredge.Request {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	return slices.Clone(server.requests)
+}
+
+// Fail makes the next count requests to endpoint fail with statusCode and message, e.g. 500 or 429 "Too many requests".
+func (server *Server) Fail(endpoint golaredge.Endpoint, statusCode int, message string, count int) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	server.failures[endpoint] = &failure{statusCode: statusCode, message: message, remaining: count}
+}
+
+func (server *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	request, err := golaredge.ParseRequest(r.URL.String())
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	spec, ok := request.Endpoint.Spec()
+	handler, handled := handlers[request.Endpoint]
+
+	if !ok || !handled {
+		http.Error(w, "Not found", http.StatusNotFound)
+
+		return
+	}
+
+	release, statusCode, message := server.admit(request, spec.Auth, r.URL.Query().Get("api_key"))
+
+	if statusCode != http.StatusOK {
+		http.Error(w, message, statusCode)
+
+		return
+	}
+
+	defer release()
+
+	time.Sleep(server.config.Latency)
+
+	sites, statusCode := server.permittedSites(request, r.URL.Query().Get("api_key"))
+
+	if statusCode != http.StatusOK {
+		http.Error(w, "Forbidden", statusCode)
+
+		return
+	}
+
+	response, err := handler(call{request: request, sites: sites, now: server.config.Now()})
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	if image, ok := response.([]byte); ok {
+		w.Header().Set("Content-Type", "image/png")
+		w.Write(image)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// admit records request and checks it against the api key, the limits and the injected failures.
+// The returned function must be called once the request has been answered.
+func (server *Server) admit(request golaredge.Request, auth golaredge.AuthMode, apiKey string) (func(), int, string) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	server.resetIfNewDay()
+	server.requests = append(server.requests, request)
+
+	if auth == golaredge.AuthAPIKey {
+		if _, ok := server.config.APIKeys[apiKey]; !ok {
+			return nil, http.StatusForbidden, "Invalid token"
+		}
+
+		server.usage[apiKey]++
+
+		if server.usage[apiKey] > server.config.DailyLimit {
+			return nil, http.StatusTooManyRequests, "Too many requests"
+		}
+	}
+
+	if server.active >= server.config.MaxConcurrent {
+		return nil, http.StatusTooManyRequests, "Too many concurrent requests"
+	}
+
+	if failure := server.failures[request.Endpoint]; failure != nil && failure.remaining > 0 {
+		failure.remaining--
+
+		return nil, failure.statusCode, failure.message
+	}
+
+	server.active++
+
+	return server.release, http.StatusOK, ""
+}
+
+func (server *Server) release() {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	server.active--
+}
+
+// resetIfNewDay clears the daily usage once the day (UTC) changes. The mutex must be held.
+func (server *Server) resetIfNewDay() {
+	day := server.config.Now().UTC().Format("2006-01-02")
+
+	if day != server.day {
+		server.day = day
+		server.usage = map[string]int{}
+	}
+}
+
+// permittedSites returns the sites of the path of request apiKey can access.
+// A single site the key cannot access is forbidden, such sites are left out of a bulk request.
+func (server *Server) permittedSites(request golaredge.Request, apiKey string) ([]Site, int) {
+	permitted := server.config.APIKeys[apiKey]
+	allowed := func(siteId int) bool {
+		_, exists := server.sites[siteId]
+
+		return exists && (permitted == nil || slices.Contains(permitted, siteId))
+	}
+
+	if siteId, ok := request.PathParams["siteId"]; ok {
+		id, err := strconv.Atoi(siteId)
+
+		if err != nil || !allowed(id) {
+			return nil, http.StatusForbidden
+		}
+
+		return []Site{server.sites[id]}, http.StatusOK
+	}
+
+	sites := []Site{}
+
+	if siteIds, ok := request.PathParams["siteIds"]; ok {
+		for _, siteId := range strings.Split(siteIds, ",") {
+			if id, err := strconv.Atoi(siteId); err == nil && allowed(id) {
+				sites = append(sites, server.sites[id])
+			}
+		}
+
+		return sites, http.StatusOK
+	}
+
+	for _, site := range server.config.Sites {
+		if allowed(site.Id) {
+			sites = append(sites, server.sites[site.Id])
+		}
+	}
+
+	return sites, http.StatusOK
+}
@@ -0,0 +1,149 @@
+package fakeapi
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	golaredge "github.com/Adrigorithm/GolarEdge"
+)
+
+var testNow = time.Date(2024, 6, 21, 15, 0, 0, 0, time.UTC)
+
+func newTestServer(t *testing.T, config Config) *Server {
+	t.Helper()
+
+	config.Now = func() time.Time { return testNow }
+	server, err := NewServer(config)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func newTestClient(server *Server, apiKey string) *golaredge.Client {
+	return golaredge.NewClient(apiKey, golaredge.WithBaseURL(server.URL()), golaredge.WithRateLimiter(nil))
+}
+
+// TestServerProduction checks that the site produces during the day only and that its meters add up.
+func TestServerProduction(t *testing.T) {
+	server := newTestServer(t, DefaultConfig())
+	client := newTestClient(server, "test-key")
+	location, _ := time.LoadLocation("Europe/Brussels")
+	day := time.Date(2024, 6, 20, 0, 0, 0, 0, location)
+
+	energy, err := client.GetSiteEnergy(t.Context(), golaredge.SiteEnergyParams{SiteId: 1, StartDate: day, EndDate: day, TimeUnit: golaredge.TimeUnitHour})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	values := energy.Energy.Values
+
+	if len(values) != 24 || *values[2].Value != 0 || *values[13].Value < 3000 {
+		t.Errorf("got %d values, %v at 02:00 and %v at 13:00", len(values), *values[2].Value, *values[13].Value)
+	}
+
+	details, err := client.GetSiteEnergyDetailed(t.Context(), golaredge.SiteEnergyDetailedParams{SiteId: 1, StartTime: day, EndTime: day.AddDate(0, 0, 1)})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	meters := map[string]float64{}
+
+	for _, meter := range details.EnergyDetails.Meters {
+		meters[meter.Type] = *meter.Values[0].Value
+	}
+
+	if produced := meters["SelfConsumption"] + meters["FeedIn"]; len(meters) != 5 || produced-meters["Production"] > 0.1 || meters["Production"]-produced > 0.1 {
+		t.Errorf("production %v is not self consumption + feed in: %v", meters["Production"], meters)
+	}
+
+	storage, err := client.GetStorageInformation(t.Context(), golaredge.StorageInformationParams{SiteId: 1, StartTime: day, EndTime: day.AddDate(0, 0, 1)})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if storage.StorageData.BatteryCount != 1 || storage.StorageData.Batteries[0].TelemetryCount != 96 {
+		t.Errorf("got %+v", storage.StorageData)
+	}
+
+	future, err := client.GetSitePower(t.Context(), golaredge.SitePowerParams{SiteId: 1, StartTime: testNow.In(location), EndTime: testNow.In(location).Add(time.Hour)})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if last := future.Power.Values[len(future.Power.Values)-1]; last.Value != nil {
+		t.Errorf("got %v for %s, want no value after now", *last.Value, last.Date)
+	}
+}
+
+// TestServerPermissions checks that an api key only accesses its sites.
+func TestServerPermissions(t *testing.T) {
+	config := DefaultConfig()
+	config.Sites = append(config.Sites, Site{Id: 2, Name: "Other site", PeakPower: 4})
+	config.APIKeys = map[string][]int{"site-key": {1}}
+	server := newTestServer(t, config)
+	client := newTestClient(server, "site-key")
+
+	if _, err := client.GetSiteOverview(t.Context(), golaredge.SiteOverviewParams{SiteId: 2}); !errors.Is(err, golaredge.ErrForbidden) {
+		t.Errorf("error = %v, want ErrForbidden", err)
+	}
+
+	bulk, err := client.GetSiteOverviewBulk(t.Context(), golaredge.SiteOverviewBulkParams{SiteIds: []int{1, 2}})
+
+	if err != nil || bulk.SitesOverviews.Count != 1 || bulk.SitesOverviews.SiteEnergyList[0].SiteId != 1 {
+		t.Errorf("got %+v, %v", bulk, err)
+	}
+
+	if _, err := newTestClient(server, "wrong-key").GetSiteOverview(t.Context(), golaredge.SiteOverviewParams{SiteId: 1}); !errors.Is(err, golaredge.ErrForbidden) {
+		t.Errorf("error = %v, want ErrForbidden", err)
+	}
+
+	if _, err := newTestClient(server, "wrong-key").GetCurrentVersion(t.Context()); err != nil {
+		t.Errorf("error = %v, the version endpoints need no api key", err)
+	}
+}
+
+// TestServerLimits checks that the daily quota and the injected failures are reported as the API does.
+func TestServerLimits(t *testing.T) {
+	config := DefaultConfig()
+	config.DailyLimit = 2
+	server := newTestServer(t, config)
+	client := newTestClient(server, "test-key")
+
+	server.Fail(golaredge.EndpointSiteDetails, http.StatusInternalServerError, "Internal error", 1)
+
+	if _, err := client.GetSite(t.Context(), golaredge.SiteParams{SiteId: 1}); !errors.Is(err, golaredge.ErrServerError) {
+		t.Errorf("error = %v, want ErrServerError", err)
+	}
+
+	if site, err := client.GetSite(t.Context(), golaredge.SiteParams{SiteId: 1}); err != nil || site.Details.Name != "Fake site" {
+		t.Errorf("got %+v, %v", site.Details, err)
+	}
+
+	if _, err := client.GetSite(t.Context(), golaredge.SiteParams{SiteId: 1}); !errors.Is(err, golaredge.ErrQuotaExceeded) {
+		t.Errorf("error = %v, want ErrQuotaExceeded", err)
+	}
+
+	if usage := server.Usage("test-key"); usage != 3 || len(server.Requests()) != 3 {
+		t.Errorf("usage = %d, requests = %d", usage, len(server.Requests()))
+	}
+}
+
+// TestServerEndpoints checks that the server implements every endpoint of the registry.
+func TestServerEndpoints(t *testing.T) {
+	for _, spec := range golaredge.Endpoints() {
+		if _, ok := handlers[spec.Endpoint]; !ok {
+			t.Errorf("%s is not implemented", spec.Endpoint)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package fakeapi
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	golaredge "github.com/Adrigorithm/GolarEdge"
+)
+
+func (site Site) details(now time.Time) golaredge.Site {
+	path := fmt.Sprintf("/site/%d/", site.Id)
+
+	return golaredge.Site{
+		Id:               site.Id,
+		Name:             site.Name,
+		AccountId:        site.AccountId,
+		Status:           "Active",
+		PeakPower:        float32(site.PeakPower),
+		LastUpdateTime:   golaredge.Timestamp{Time: periodStart(now.In(site.location), golaredge.TimeUnitQuarterOfAnHour)},
+		Currency:         "EUR",
+		InstallationDate: golaredge.Timestamp{Time: site.InstallationDate},
+		PtoDate:          golaredge.Timestamp{Time: startOfDay(site.InstallationDate)},
+		SiteType:         "Optimizers and inverters",
+		Location:         site.locationDetails(),
+		AlertSeverity:    "NONE",
+		Uris: golaredge.Uris{
+			SITE_IMAGE:      path + "siteImage/site.png",
+			INSTALLER_IMAGE: path + "installerImage/installer.png",
+			DATA_PERIOD:     path + "dataPeriod",
+			DETAILS:         path + "details",
+			OVERVIEW:        path + "overview",
+		},
+		PublicSettings: golaredge.PublicSettings{IsPublic: false},
+	}
+}
+
+func (site Site) locationDetails() golaredge.Location {
+	return golaredge.Location{Country: "Belgium", City: "Brussels", Address: "Rue de la Loi 16", Zip: "1000", TimeZone: site.location.String(), CountryCode: "BE"}
+}
+
+func (site Site) dataPeriod(now time.Time) golaredge.DataPeriod {
+	return golaredge.DataPeriod{StartDate: golaredge.Timestamp{Time: startOfDay(site.InstallationDate)}, EndDate: golaredge.Timestamp{Time: startOfDay(now.In(site.location))}}
+}
+
+// meters returns the meters of the site, only the production if it has no meters.
+func (site Site) meters() []golaredge.MeterType {
+	if !site.Meters {
+		return []golaredge.MeterType{golaredge.MeterProduction}
+	}
+
+	return []golaredge.MeterType{golaredge.MeterProduction, golaredge.MeterConsumption, golaredge.MeterSelfConsumption, golaredge.MeterFeedIn, golaredge.MeterPurchased}
+}
+
+func (site Site) measuredBy() string {
+	if site.Meters {
+		return "METER"
+	}
+
+	return "INVERTER"
+}
+
+// serial returns the serial number of a device of the site, e.g. "INV-1" for the inverter of site 1.
+func (site Site) serial(device string) string {
+	return fmt.Sprintf("%s-%d", device, site.Id)
+}
+
+func (site Site) energy(call call, timeUnit golaredge.TimeUnit) (golaredge.MeasuredValues, error) {
+	from, to, err := call.timeRange(site, "startDate", "endDate")
+
+	if err != nil {
+		return golaredge.MeasuredValues{}, err
+	}
+
+	samples := site.simulate(from, earliest(to, call.now))
+
+	return golaredge.MeasuredValues{MeasuredBy: site.measuredBy(), Values: series(samples, from, to, call.now, timeUnit, productionOf, false)}, nil
+}
+
+func (site Site) power(call call) (golaredge.MeasuredValues, error) {
+	from, to, err := call.timeRange(site, "startTime", "endTime")
+
+	if err != nil {
+		return golaredge.MeasuredValues{}, err
+	}
+
+	samples := site.simulate(from, earliest(to, call.now))
+
+	return golaredge.MeasuredValues{MeasuredBy: site.measuredBy(), Values: series(samples, from, to, call.now, golaredge.TimeUnitQuarterOfAnHour, productionOf, true)}, nil
+}
+
+func (site Site) timeFrameEnergy(call call) (golaredge.SiteEnergyTimePeriod, error) {
+	from, to, err := call.timeRange(site, "startDate", "endDate")
+
+	if err != nil {
+		return golaredge.SiteEnergyTimePeriod{}, err
+	}
+
+	before := energy(site.simulate(site.InstallationDate, earliest(from, call.now)), productionOf)
+	produced := energy(site.simulate(from, earliest(to, call.now)), productionOf)
+
+	return golaredge.SiteEnergyTimePeriod{
+		Energy:              produced,
+		Unit:                "Wh",
+		MeasuredBy:          site.measuredBy(),
+		StartLifetimeEnergy: golaredge.LifetimeEnergy{Date: golaredge.Timestamp{Time: from}, Energy: before, Unit: "Wh"},
+		EndLifetimeEnergy:   golaredge.LifetimeEnergy{Date: golaredge.Timestamp{Time: to.AddDate(0, 0, -1)}, Energy: round(before + produced), Unit: "Wh"},
+	}, nil
+}
+
+func (site Site) overview(now time.Time) golaredge.SiteOverview {
+	now = now.In(site.location)
+	samples := site.simulate(site.InstallationDate, now.Add(time.Nanosecond))
+	since := func(start time.Time) golaredge.EnergyRevenue {
+		first, _ := slices.BinarySearchFunc(samples, start, func(sample sample, start time.Time) int { return sample.time.Compare(start) })
+		produced := energy(samples[first:], productionOf)
+
+		return golaredge.EnergyRevenue{Energy: produced, Revenue: round(produced / 1000 * pricePerKWh)}
+	}
+
+	overview := golaredge.SiteOverview{
+		LastUpdateTime: golaredge.Timestamp{Time: periodStart(now, golaredge.TimeUnitQuarterOfAnHour)},
+		LifeTimeData:   since(site.InstallationDate),
+		LastYearData:   since(periodStart(now, golaredge.TimeUnitYear)),
+		LastMonthData:  since(periodStart(now, golaredge.TimeUnitMonth)),
+		LastDayData:    since(startOfDay(now)),
+		MeasuredBy:     site.measuredBy(),
+	}
+
+	if len(samples) > 0 {
+		overview.CurrentPower.Power = round(samples[len(samples)-1].production)
+	}
+
+	return overview
+}
+
+// detailedMeters returns the series of the meters selected by the meters parameter, either as energy or as average power.
+func (site Site) detailedMeters(call call, timeUnit golaredge.TimeUnit, average bool) ([]golaredge.MeterValues, error) {
+	from, to, err := call.timeRange(site, "startTime", "endTime")
+
+	if err != nil {
+		return nil, err
+	}
+
+	selected, err := call.meters(site)
+
+	if err != nil {
+		return nil, err
+	}
+
+	samples := site.simulate(from, earliest(to, call.now))
+	meters := []golaredge.MeterValues{}
+
+	for _, meter := range selected {
+		value := func(sample sample) float64 { return sample.meter(meter) }
+		meters = append(meters, golaredge.MeterValues{Type: meter.String(), Values: series(samples, from, to, call.now, timeUnit, value, average)})
+	}
+
+	return meters, nil
+}
@@ -0,0 +1,3 @@
+module github.com/Adrigorithm/GolarEdge
+
+go 1.24.2
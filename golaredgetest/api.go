@@ -0,0 +1,135 @@
+package golaredgetest
+
+import (
+	"context"
+
+	golaredge "github.com/Adrigorithm/GolarEdge"
+)
+
+// Site Data API
+
+func (fake *Fake) GetSiteList(ctx context.Context, params golaredge.SiteListParams) (golaredge.SiteListResponse, error) {
+	return call[golaredge.SiteListResponse](ctx, fake, golaredge.EndpointSiteList, params)
+}
+
+func (fake *Fake) GetSite(ctx context.Context, params golaredge.SiteParams) (golaredge.SiteDetailsResponse, error) {
+	return call[golaredge.SiteDetailsResponse](ctx, fake, golaredge.EndpointSiteDetails, params)
+}
+
+func (fake *Fake) GetSiteDataStartAndEndDates(ctx context.Context, params golaredge.SiteDataStartAndEndDatesParams) (golaredge.SiteDataPeriodResponse, error) {
+	return call[golaredge.SiteDataPeriodResponse](ctx, fake, golaredge.EndpointSiteDataPeriod, params)
+}
+
+func (fake *Fake) GetSiteDataStartAndEndDatesBulk(ctx context.Context, params golaredge.SiteDataStartAndEndDatesBulkParams) (golaredge.SiteDataPeriodBulkResponse, error) {
+	return call[golaredge.SiteDataPeriodBulkResponse](ctx, fake, golaredge.EndpointSiteDataPeriodBulk, params)
+}
+
+func (fake *Fake) GetSiteEnergy(ctx context.Context, params golaredge.SiteEnergyParams) (golaredge.SiteEnergyResponse, error) {
+	return call[golaredge.SiteEnergyResponse](ctx, fake, golaredge.EndpointSiteEnergy, params)
+}
+
+func (fake *Fake) GetSiteEnergyBulk(ctx context.Context, params golaredge.SiteEnergyBulkParams) (golaredge.SiteEnergyBulkResponse, error) {
+	return call[golaredge.SiteEnergyBulkResponse](ctx, fake, golaredge.EndpointSiteEnergyBulk, params)
+}
+
+func (fake *Fake) GetSiteEnergyTimePeriod(ctx context.Context, params golaredge.SiteEnergyTimePeriodParams) (golaredge.SiteEnergyTimePeriodResponse, error) {
+	return call[golaredge.SiteEnergyTimePeriodResponse](ctx, fake, golaredge.EndpointSiteTimeFrameEnergy, params)
+}
+
+func (fake *Fake) GetSiteEnergyTimePeriodBulk(ctx context.Context, params golaredge.SiteEnergyTimePeriodBulkParams) (golaredge.SiteEnergyTimePeriodBulkResponse, error) {
+	return call[golaredge.SiteEnergyTimePeriodBulkResponse](ctx, fake, golaredge.EndpointSiteTimeFrameEnergyBulk, params)
+}
+
+func (fake *Fake) GetSitePower(ctx context.Context, params golaredge.SitePowerParams) (golaredge.SitePowerResponse, error) {
+	return call[golaredge.SitePowerResponse](ctx, fake, golaredge.EndpointSitePower, params)
+}
+
+func (fake *Fake) GetSitePowerBulk(ctx context.Context, params golaredge.SitePowerBulkParams) (golaredge.SitePowerBulkResponse, error) {
+	return call[golaredge.SitePowerBulkResponse](ctx, fake, golaredge.EndpointSitePowerBulk, params)
+}
+
+func (fake *Fake) GetSiteOverview(ctx context.Context, params golaredge.SiteOverviewParams) (golaredge.SiteOverviewResponse, error) {
+	return call[golaredge.SiteOverviewResponse](ctx, fake, golaredge.EndpointSiteOverview, params)
+}
+
+func (fake *Fake) GetSiteOverviewBulk(ctx context.Context, params golaredge.SiteOverviewBulkParams) (golaredge.SiteOverviewBulkResponse, error) {
+	return call[golaredge.SiteOverviewBulkResponse](ctx, fake, golaredge.EndpointSiteOverviewBulk, params)
+}
+
+func (fake *Fake) GetSitePowerDetailed(ctx context.Context, params golaredge.SitePowerDetailedParams) (golaredge.SitePowerDetailedResponse, error) {
+	return call[golaredge.SitePowerDetailedResponse](ctx, fake, golaredge.EndpointSitePowerDetails, params)
+}
+
+func (fake *Fake) GetSiteEnergyDetailed(ctx context.Context, params golaredge.SiteEnergyDetailedParams) (golaredge.SiteEnergyDetailedResponse, error) {
+	return call[golaredge.SiteEnergyDetailedResponse](ctx, fake, golaredge.EndpointSiteEnergyDetails, params)
+}
+
+func (fake *Fake) GetSitePowerFlow(ctx context.Context, params golaredge.SitePowerFlowParams) (golaredge.SitePowerFlowResponse, error) {
+	return call[golaredge.SitePowerFlowResponse](ctx, fake, golaredge.EndpointSiteCurrentPowerFlow, params)
+}
+
+func (fake *Fake) GetStorageInformation(ctx context.Context, params golaredge.StorageInformationParams) (golaredge.StorageInformationResponse, error) {
+	return call[golaredge.StorageInformationResponse](ctx, fake, golaredge.EndpointSiteStorageData, params)
+}
+
+func (fake *Fake) GetSiteImage(ctx context.Context, params golaredge.SiteImageParams) ([]byte, error) {
+	return call[[]byte](ctx, fake, golaredge.EndpointSiteImage, params)
+}
+
+func (fake *Fake) GetSiteEnvironmentalBenefits(ctx context.Context, params golaredge.SiteEnvironmentalBenefitsParams) (golaredge.SiteEnvironmentalBenefitsResponse, error) {
+	return call[golaredge.SiteEnvironmentalBenefitsResponse](ctx, fake, golaredge.EndpointSiteEnvironmentalBenefits, params)
+}
+
+func (fake *Fake) GetInstallerImage(ctx context.Context, params golaredge.SiteImageParams) ([]byte, error) {
+	return call[[]byte](ctx, fake, golaredge.EndpointSiteInstallerImage, params)
+}
+
+// Site Equipment API
+
+func (fake *Fake) GetComponentsList(ctx context.Context, params golaredge.ComponentsListParams) (golaredge.ComponentsListResponse, error) {
+	return call[golaredge.ComponentsListResponse](ctx, fake, golaredge.EndpointComponentsList, params)
+}
+
+func (fake *Fake) GetInventory(ctx context.Context, params golaredge.InventoryParams) (golaredge.InventoryResponse, error) {
+	return call[golaredge.InventoryResponse](ctx, fake, golaredge.EndpointSiteInventory, params)
+}
+
+func (fake *Fake) GetInverterTechnicalData(ctx context.Context, params golaredge.InverterTechnicalDataParams) (golaredge.InverterTechnicalDataResponse, error) {
+	return call[golaredge.InverterTechnicalDataResponse](ctx, fake, golaredge.EndpointInverterTechnicalData, params)
+}
+
+func (fake *Fake) GetEquipmentChangeLog(ctx context.Context, params golaredge.EquipmentChangeLogParams) (golaredge.EquipmentChangeLogResponse, error) {
+	return call[golaredge.EquipmentChangeLogResponse](ctx, fake, golaredge.EndpointEquipmentChangeLog, params)
+}
+
+// Account List API
+
+func (fake *Fake) GetAccountList(ctx context.Context, params golaredge.AccountListParams) (golaredge.AccountListResponse, error) {
+	return call[golaredge.AccountListResponse](ctx, fake, golaredge.EndpointAccountList, params)
+}
+
+// Meters API
+
+func (fake *Fake) GetMetersData(ctx context.Context, params golaredge.MetersDataParams) (golaredge.MetersDataResponse, error) {
+	return call[golaredge.MetersDataResponse](ctx, fake, golaredge.EndpointSiteMeters, params)
+}
+
+// Sensors API
+
+func (fake *Fake) GetSensorsList(ctx context.Context, params golaredge.SensorsListParams) (golaredge.SensorsListResponse, error) {
+	return call[golaredge.SensorsListResponse](ctx, fake, golaredge.EndpointSensorsList, params)
+}
+
+func (fake *Fake) GetSensorData(ctx context.Context, params golaredge.SensorDataParams) (golaredge.SensorDataResponse, error) {
+	return call[golaredge.SensorDataResponse](ctx, fake, golaredge.EndpointSiteSensors, params)
+}
+
+// API Versions
+
+func (fake *Fake) GetCurrentVersion(ctx context.Context) (golaredge.CurrentVersionResponse, error) {
+	return call[golaredge.CurrentVersionResponse](ctx, fake, golaredge.EndpointCurrentVersion, nil)
+}
+
+func (fake *Fake) GetSupportedVersion(ctx context.Context) (golaredge.SupportedVersionResponse, error) {
+	return call[golaredge.SupportedVersionResponse](ctx, fake, golaredge.EndpointSupportedVersion, nil)
+}
@@ -0,0 +1,238 @@
+// Package golaredgetest provides Fake, a programmable golaredge.API to unit test code using the Monitoring API without HTTP.
+package golaredgetest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	golaredge "github.com/Adrigorithm/GolarEdge"
+)
+
+// AnySite programs a response or an error for every site, and for the endpoints that do not take a single site.
+const AnySite = 0
+
+// ErrNoResponse is returned for a call that has neither a response nor an error programmed.
+var ErrNoResponse = errors.New("no response programmed")
+
+// Call is a call of an endpoint method of the fake.
+type Call struct {
+	Endpoint golaredge.Endpoint
+
+	// SiteId is the site of the call, AnySite if the endpoint does not take a single site.
+	SiteId int
+
+	// Params are the params passed to the method, nil for the version endpoints.
+	Params any
+
+	// Request is the request the real client would have sent for Params, its zero value if Params are invalid.
+	Request golaredge.Request
+}
+
+type key struct {
+	endpoint golaredge.Endpoint
+	siteId   int
+}
+
+// Fake implements golaredge.API with the responses and errors programmed with Respond and Fail, and records every call.
+// Like the real client, it returns the validation error of invalid params, the error of a done context, then the programmed
+// error or response of the site of the call (or of AnySite) and ErrNoResponse if there is none.
+// It is safe for concurrent use.
+type Fake struct {
+	mutex     sync.Mutex
+	responses map[key]any
+	errors    map[key]error
+	calls     []Call
+}
+
+var _ golaredge.API = (*Fake)(nil)
+
+func NewFake() *Fake {
+	return &Fake{responses: map[key]any{}, errors: map[key]error{}}
+}
+
+// Respond programs the response of endpoint for siteId (or AnySite), e.g. a golaredge.SiteOverviewResponse for
+// golaredge.EndpointSiteOverview. The images are programmed as []byte for golaredge.EndpointSiteImage and golaredge.EndpointSiteInstallerImage.
+func (fake *Fake) Respond(endpoint golaredge.Endpoint, siteId int, response any) {
+	fake.mutex.Lock()
+	defer fake.mutex.Unlock()
+
+	fake.responses[key{endpoint, siteId}] = response
+}
+
+// Fail programs the error of endpoint for siteId (or AnySite), it takes precedence over the responses. A nil err removes it.
+func (fake *Fake) Fail(endpoint golaredge.Endpoint, siteId int, err error) {
+	fake.mutex.Lock()
+	defer fake.mutex.Unlock()
+
+	if err == nil {
+		delete(fake.errors, key{endpoint, siteId})
+
+		return
+	}
+
+	fake.errors[key{endpoint, siteId}] = err
+}
+
+// Calls returns the calls made so far, in order.
+func (fake *Fake) Calls() []Call {
+	fake.mutex.Lock()
+	defer fake.mutex.Unlock()
+
+	return append([]Call{}, fake.calls...)
+}
+
+// CallsTo returns the calls of endpoint made so far, in order.
+func (fake *Fake) CallsTo(endpoint golaredge.Endpoint) []Call {
+	calls := []Call{}
+
+	for _, call := range fake.Calls() {
+		if call.Endpoint == endpoint {
+			calls = append(calls, call)
+		}
+	}
+
+	return calls
+}
+
+// AssertCalled fails t unless endpoint was called with params. Params are compared by the request they build, so that
+// e.g. the order of the values of a filter or the location of a time that is sent as a date do not matter.
+func (fake *Fake) AssertCalled(t testing.TB, endpoint golaredge.Endpoint, params any) {
+	t.Helper()
+
+	want, err := request(endpoint, params)
+
+	if err != nil {
+		t.Fatalf("invalid params for %s: %v", endpoint, err)
+	}
+
+	calls := fake.CallsTo(endpoint)
+	called := make([]string, len(calls))
+
+	for i := range calls {
+		if calls[i].Request.Canonical() == want.Canonical() {
+			return
+		}
+
+		called[i] = calls[i].Request.Canonical()
+	}
+
+	t.Errorf("%s was not called, got %d calls of %s: %s", want.Canonical(), len(calls), endpoint, strings.Join(called, ", "))
+}
+
+// AssertCallCount fails t unless endpoint was called count times.
+func (fake *Fake) AssertCallCount(t testing.TB, endpoint golaredge.Endpoint, count int) {
+	t.Helper()
+
+	if calls := fake.CallsTo(endpoint); len(calls) != count {
+		t.Errorf("%s was called %d times, want %d", endpoint, len(calls), count)
+	}
+}
+
+// call records a call of endpoint and returns its programmed outcome.
+func call[R any](ctx context.Context, fake *Fake, endpoint golaredge.Endpoint, params any) (R, error) {
+	var result R
+
+	request, err := request(endpoint, params)
+	siteId, _ := strconv.Atoi(request.PathParams["siteId"])
+
+	fake.mutex.Lock()
+	defer fake.mutex.Unlock()
+
+	fake.calls = append(fake.calls, Call{Endpoint: endpoint, SiteId: siteId, Params: params, Request: request})
+
+	if err != nil {
+		return result, err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return result, err
+	}
+
+	for _, key := range []key{{endpoint, siteId}, {endpoint, AnySite}} {
+		if err, ok := fake.errors[key]; ok {
+			return result, err
+		}
+	}
+
+	for _, key := range []key{{endpoint, siteId}, {endpoint, AnySite}} {
+		if response, ok := fake.responses[key]; ok {
+			typed, ok := response.(R)
+
+			if !ok {
+				return result, fmt.Errorf("the response of %s is a %T, want %T", endpoint, response, result)
+			}
+
+			return typed, nil
+		}
+	}
+
+	return result, fmt.Errorf("%w for %s of site %d", ErrNoResponse, endpoint, siteId)
+}
+
+// request returns the request built by the Get*Request function of endpoint for params.
+func request(endpoint golaredge.Endpoint, params any) (golaredge.Request, error) {
+	build, ok := builders[endpoint]
+
+	if !ok {
+		return golaredge.Request{}, fmt.Errorf("%s is not an endpoint of the fake", endpoint)
+	}
+
+	return build(params)
+}
+
+// builder adapts a Get*Request function to the params of any type.
+func builder[P any](get func(params P) (golaredge.Request, error)) func(params any) (golaredge.Request, error) {
+	return func(params any) (golaredge.Request, error) {
+		typed, ok := params.(P)
+
+		if !ok {
+			return golaredge.Request{}, fmt.Errorf("params are a %T, want %T", params, typed)
+		}
+
+		return get(typed)
+	}
+}
+
+func versionBuilder(get func() golaredge.Request) func(params any) (golaredge.Request, error) {
+	return func(params any) (golaredge.Request, error) {
+		return get(), nil
+	}
+}
+
+// builders are the Get*Request functions of the endpoints of the methods of golaredge.API.
+var builders = map[golaredge.Endpoint]func(params any) (golaredge.Request, error){
+	golaredge.EndpointSiteList:                  builder(golaredge.GetSiteListRequest),
+	golaredge.EndpointSiteDetails:               builder(golaredge.GetSiteRequest),
+	golaredge.EndpointSiteDataPeriod:            builder(golaredge.GetSiteDataStartAndEndDatesRequest),
+	golaredge.EndpointSiteDataPeriodBulk:        builder(golaredge.GetSiteDataStartAndEndDatesBulkRequest),
+	golaredge.EndpointSiteEnergy:                builder(golaredge.GetSiteEnergyRequest),
+	golaredge.EndpointSiteEnergyBulk:            builder(golaredge.GetSiteEnergyBulkRequest),
+	golaredge.EndpointSiteTimeFrameEnergy:       builder(golaredge.GetSiteEnergyTimePeriodRequest),
+	golaredge.EndpointSiteTimeFrameEnergyBulk:   builder(golaredge.GetSiteEnergyTimePeriodBulkRequest),
+	golaredge.EndpointSitePower:                 builder(golaredge.GetSitePowerRequest),
+	golaredge.EndpointSitePowerBulk:             builder(golaredge.GetSitePowerBulkRequest),
+	golaredge.EndpointSiteOverview:              builder(golaredge.GetSiteOverviewRequest),
+	golaredge.EndpointSiteOverviewBulk:          builder(golaredge.GetSiteOverviewBulkRequest),
+	golaredge.EndpointSitePowerDetails:          builder(golaredge.GetSitePowerDetailedRequest),
+	golaredge.EndpointSiteEnergyDetails:         builder(golaredge.GetSiteEnergyDetailedRequest),
+	golaredge.EndpointSiteCurrentPowerFlow:      builder(golaredge.GetSitePowerFlowRequest),
+	golaredge.EndpointSiteStorageData:           builder(golaredge.GetStorageInformationRequest),
+	golaredge.EndpointSiteImage:                 builder(golaredge.GetSiteImageRequest),
+	golaredge.EndpointSiteEnvironmentalBenefits: builder(golaredge.GetSiteEnvironmentalBenefitsRequest),
+	golaredge.EndpointSiteInstallerImage:        builder(golaredge.GetInstallerImageRequest),
+	golaredge.EndpointComponentsList:            builder(golaredge.GetComponentsListRequest),
+	golaredge.EndpointSiteInventory:             builder(golaredge.GetInventoryRequest),
+	golaredge.EndpointInverterTechnicalData:     builder(golaredge.GetInverterTechnicalDataRequest),
+	golaredge.EndpointEquipmentChangeLog:        builder(golaredge.GetEquipmentChangeLogRequest),
+	golaredge.EndpointAccountList:               builder(golaredge.GetAccountListRequest),
+	golaredge.EndpointSiteMeters:                builder(golaredge.GetMetersDataRequest),
+	golaredge.EndpointSensorsList:               builder(golaredge.GetSensorsListRequest),
+	golaredge.EndpointSiteSensors:               builder(golaredge.GetSensorDataRequest),
+	golaredge.EndpointCurrentVersion:            versionBuilder(golaredge.GetCurrentVersionRequest),
+	golaredge.EndpointSupportedVersion:          versionBuilder(golaredge.GetSupportedVersionRequest),
+}
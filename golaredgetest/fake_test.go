@@ -0,0 +1,83 @@
+package golaredgetest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	golaredge "github.com/Adrigorithm/GolarEdge"
+)
+
+// currentPower is code under test, it only depends on golaredge.API.
+func currentPower(ctx context.Context, api golaredge.API, siteIds []int) (float64, error) {
+	total := 0.0
+
+	for _, siteId := range siteIds {
+		overview, err := api.GetSiteOverview(ctx, golaredge.SiteOverviewParams{SiteId: siteId})
+
+		if err != nil {
+			return total, err
+		}
+
+		total += overview.Overview.CurrentPower.Power
+	}
+
+	return total, nil
+}
+
+// TestFake checks that the programmed responses and errors are returned per site and that the calls are recorded.
+func TestFake(t *testing.T) {
+	fake := NewFake()
+	fake.Respond(golaredge.EndpointSiteOverview, AnySite, golaredge.SiteOverviewResponse{Overview: golaredge.SiteOverview{CurrentPower: golaredge.CurrentPower{Power: 100}}})
+	fake.Respond(golaredge.EndpointSiteOverview, 2, golaredge.SiteOverviewResponse{Overview: golaredge.SiteOverview{CurrentPower: golaredge.CurrentPower{Power: 50}}})
+
+	if power, err := currentPower(t.Context(), fake, []int{1, 2}); err != nil || power != 150 {
+		t.Errorf("got %v, %v, want 150", power, err)
+	}
+
+	fake.Fail(golaredge.EndpointSiteOverview, 3, golaredge.ErrForbidden)
+
+	if _, err := currentPower(t.Context(), fake, []int{1, 3}); !errors.Is(err, golaredge.ErrForbidden) {
+		t.Errorf("error = %v, want ErrForbidden", err)
+	}
+
+	fake.AssertCallCount(t, golaredge.EndpointSiteOverview, 4)
+	fake.AssertCalled(t, golaredge.EndpointSiteOverview, golaredge.SiteOverviewParams{SiteId: 3})
+
+	if calls := fake.Calls(); calls[3].SiteId != 3 || calls[3].Request.Path() != "site/3/overview" {
+		t.Errorf("got %+v", calls[3])
+	}
+
+	if _, err := fake.GetSitePowerFlow(t.Context(), golaredge.SitePowerFlowParams{SiteId: 1}); !errors.Is(err, ErrNoResponse) {
+		t.Errorf("error = %v, want ErrNoResponse", err)
+	}
+
+	fake.Respond(golaredge.EndpointCurrentVersion, AnySite, golaredge.SupportedVersionResponse{})
+
+	if _, err := fake.GetCurrentVersion(t.Context()); err == nil {
+		t.Error("error = nil, want an error for a response of the wrong type")
+	}
+}
+
+// TestFakeParams checks that invalid params fail like they do with the client and that params are compared by their request.
+func TestFakeParams(t *testing.T) {
+	fake := NewFake()
+	fake.Respond(golaredge.EndpointSiteEnergyBulk, AnySite, golaredge.SiteEnergyBulkResponse{})
+
+	if _, err := fake.GetSiteEnergyBulk(t.Context(), golaredge.SiteEnergyBulkParams{}); !errors.Is(err, golaredge.ErrMissingSiteIDs) {
+		t.Errorf("error = %v, want ErrMissingSiteIDs", err)
+	}
+
+	location, _ := time.LoadLocation("Europe/Brussels")
+	start := time.Date(2024, 6, 1, 0, 0, 0, 0, location)
+	params := golaredge.SiteEnergyBulkParams{SiteIds: []int{2, 1}, StartDate: start, EndDate: start.AddDate(0, 0, 6), TimeUnit: golaredge.TimeUnitDay}
+
+	if _, err := fake.GetSiteEnergyBulk(t.Context(), params); err != nil {
+		t.Fatal(err)
+	}
+
+	// same dates in another location
+	params.StartDate, params.EndDate = time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 6, 7, 0, 0, 0, 0, time.UTC)
+	fake.AssertCalled(t, golaredge.EndpointSiteEnergyBulk, params)
+}
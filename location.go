@@ -0,0 +1,13 @@
+package golaredge
+
+type Location struct {
+	Country     string `json:"country"`
+	State       string `json:"state"`
+	City        string `json:"city"`
+	Address     string `json:"address"`
+	Address2    string `json:"address2"`
+	Zip         string `json:"zip"`
+	TimeZone    string `json:"timeZone"`
+	CountryCode string `json:"countryCode"`
+	StateCode   string `json:"stateCode"`
+}
@@ -1,46 +0,0 @@
-package main
-
-import (
-	"errors"
-	"fmt"
-	"math/rand"
-)
-
-
-
-// Hello returns a greeting for the named person.
-func Hello(name string) (string, error) {
-	if name == "" {
-		return "", errors.New("empty name")
-	}
-
-	message := fmt.Sprintf(randomFormat(), name)
-
-	return message, nil
-}
-
-func Hellos(names []string) (map[string]string, error) {
-	messages := make(map[string]string)
-
-	for _, name := range names {
-		message, error := Hello(name)
-
-		if error != nil {
-			return nil, error
-		}
-
-		messages[name] = message
-	}
-
-	return messages, nil
-}
-
-func randomFormat() string {
-	formats := []string{
-		"Hi, %v. Welcome!",
-		"Great to see you, %v!",
-		"Hail, %v! Well met!",
-	}
-
-	return formats[rand.Intn(len(formats))]
-}
@@ -1,7 +0,0 @@
-module a3aan.cat/main
-
-go 1.24.2
-
-replace a3aan.cat/api => ../api
-
-require a3aan.cat/api v0.0.0-00010101000000-000000000000
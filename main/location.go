@@ -1,11 +0,0 @@
-package main
-
-type Location struct {
-	country  string
-	state    string
-	city     string
-	address  string
-	address2 string
-	zip      string
-	timeZone string
-}
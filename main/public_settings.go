@@ -1,6 +0,0 @@
-package main
-
-type PublicSettings struct {
-	name     string
-	isPublic bool
-}
@@ -1,6 +0,0 @@
-package main
-
-type Uris struct {
-	PUBLIC_URL string
-	IMAGE_URL  string
-}
@@ -0,0 +1,314 @@
+package golaredge
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// MergingStats counts the calls of a client with bulk merging, see WithBulkMerging.
+type MergingStats struct {
+	// Calls is the number of calls of the single-site endpoints with a bulk variant.
+	Calls int
+
+	// Requests is the number of requests sent for them, a single-site or a bulk request per window.
+	Requests int
+}
+
+// WithBulkMerging merges the calls of the single-site endpoints with a bulk variant (GetSiteDataStartAndEndDates, GetSiteEnergy,
+// GetSiteEnergyTimePeriod, GetSitePower and GetSiteOverview) made within window with the same parameters into a single bulk request,
+// and answers every call with the part of the bulk response of its site. The first call of a window waits until the window ends,
+// a window ends early once it holds as many sites as a bulk request accepts. A window of a single site sends a single-site request.
+// Merging thus adds up to window of latency to every call that is not answered by the cache (see WithCache): cached calls skip the window,
+// and the part of every site of a bulk response is cached as the response of its single-site request.
+//
+// Merging is a middleware ordered with those of WithMiddleware, the bulk requests go through every middleware too.
+// A site missing from a bulk response (e.g. a site the api key cannot access) fails with ErrSiteNotInResponse, and a cancelled call
+// stops waiting without cancelling the bulk request of the other sites.
+func WithBulkMerging(window time.Duration) Option {
+	return func(client *Client) {
+		client.merger = &merger{client: client, window: window, batches: map[string]*mergeBatch{}}
+		client.middlewares = append(client.middlewares, client.merger.middleware)
+	}
+}
+
+// MergingStats returns the number of calls and requests of bulk merging so far, zero values if it is disabled.
+func (client *Client) MergingStats() MergingStats {
+	if client.merger == nil {
+		return MergingStats{}
+	}
+
+	client.merger.mutex.Lock()
+	defer client.merger.mutex.Unlock()
+
+	return client.merger.stats
+}
+
+// bulkMerge answers the calls of siteIds with the params of a single-site endpoint using its bulk variant.
+type bulkMerge func(ctx context.Context, client *Client, params any, siteIds []int) (map[int]any, error)
+
+// mergeable are the single-site endpoints with a bulk variant.
+var mergeable = map[Endpoint]bulkMerge{
+	EndpointSiteDataPeriod:      mergeDataPeriod,
+	EndpointSiteEnergy:          mergeEnergy,
+	EndpointSiteTimeFrameEnergy: mergeTimeFrameEnergy,
+	EndpointSitePower:           mergePower,
+	EndpointSiteOverview:        mergeOverview,
+}
+
+// mergeBatch is the calls of a window.
+type mergeBatch struct {
+	// ctx, call and next are those of the first call, to send a single-site request.
+	ctx   context.Context
+	call  Call
+	next  Handler
+	merge bulkMerge
+
+	siteIds []int
+	length  int
+
+	done      chan struct{}
+	responses map[int]any
+	err       error
+
+	// bodies are the encoded responses, every call decodes its own copy as the caller may modify it (see fetchSite).
+	bodies map[int][]byte
+}
+
+// accepts reports whether siteId is part of the batch or can be added to it.
+func (batch *mergeBatch) accepts(siteId int) bool {
+	if slices.Contains(batch.siteIds, siteId) {
+		return true
+	}
+
+	return len(batch.siteIds) < MaxBulkSiteIds && batch.length+len(strconv.Itoa(siteId))+1 <= maxBulkSiteIdsLength
+}
+
+func (batch *mergeBatch) add(siteId int) {
+	if !slices.Contains(batch.siteIds, siteId) {
+		batch.siteIds = append(batch.siteIds, siteId)
+		batch.length += len(strconv.Itoa(siteId)) + 1
+	}
+}
+
+type merger struct {
+	client *Client
+	window time.Duration
+
+	mutex   sync.Mutex
+	batches map[string]*mergeBatch
+	stats   MergingStats
+}
+
+func (merger *merger) middleware(next Handler) Handler {
+	return func(ctx context.Context, call Call) (any, error) {
+		merge, ok := mergeable[call.Request.Endpoint]
+
+		if !ok {
+			return next(ctx, call)
+		}
+
+		siteId, err := strconv.Atoi(call.Request.PathParams["siteId"])
+
+		if err != nil {
+			return next(ctx, call)
+		}
+
+		// a cached call does not wait for the window
+		if merger.client.cached(call.Request) {
+			merger.mutex.Lock()
+			merger.stats.Calls++
+			merger.mutex.Unlock()
+
+			return next(ctx, call)
+		}
+
+		batch := merger.add(ctx, call, next, merge, siteId)
+
+		select {
+		case <-batch.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+
+		if batch.err != nil {
+			return nil, batch.err
+		}
+
+		response, ok := batch.responses[siteId]
+
+		if !ok {
+			return nil, fmt.Errorf("%w: site %d", ErrSiteNotInResponse, siteId)
+		}
+
+		result := reflect.New(reflect.TypeOf(response))
+		err = json.Unmarshal(batch.bodies[siteId], result.Interface())
+
+		return result.Elem().Interface(), err
+	}
+}
+
+// add adds siteId to the batch of the parameters of call, starting a new batch if there is none or if it is full.
+func (merger *merger) add(ctx context.Context, call Call, next Handler, merge bulkMerge, siteId int) *mergeBatch {
+	// the calls of a batch share everything but the site
+	key := string(call.Request.Endpoint) + "?" + call.Request.Query.Encode()
+
+	merger.mutex.Lock()
+	defer merger.mutex.Unlock()
+
+	merger.stats.Calls++
+	batch := merger.batches[key]
+
+	if batch != nil && !batch.accepts(siteId) {
+		merger.send(key, batch)
+		batch = nil
+	}
+
+	if batch == nil {
+		batch = merger.open(ctx, key, call, next, merge)
+	}
+
+	batch.add(siteId)
+
+	if len(batch.siteIds) == MaxBulkSiteIds {
+		merger.send(key, batch)
+	}
+
+	return batch
+}
+
+// open starts the batch of key with call, to be sent at the end of the window. The mutex must be held.
+func (merger *merger) open(ctx context.Context, key string, call Call, next Handler, merge bulkMerge) *mergeBatch {
+	batch := &mergeBatch{ctx: context.WithoutCancel(ctx), call: call, next: next, merge: merge, done: make(chan struct{})}
+	merger.batches[key] = batch
+
+	time.AfterFunc(merger.window, func() {
+		merger.mutex.Lock()
+		defer merger.mutex.Unlock()
+
+		merger.send(key, batch)
+	})
+
+	return batch
+}
+
+// send closes batch and sends its request, unless it is already sent. The mutex must be held.
+func (merger *merger) send(key string, batch *mergeBatch) {
+	if merger.batches[key] != batch {
+		return
+	}
+
+	delete(merger.batches, key)
+	merger.stats.Requests++
+
+	go func() {
+		defer close(batch.done)
+
+		if len(batch.siteIds) == 1 {
+			response, err := batch.next(batch.ctx, batch.call)
+			batch.responses, batch.err = map[int]any{batch.siteIds[0]: response}, err
+			batch.bodies, batch.err = merger.encode(batch, false)
+
+			return
+		}
+
+		batch.responses, batch.err = batch.merge(batch.ctx, merger.client, batch.call.Params, batch.siteIds)
+		batch.bodies, batch.err = merger.encode(batch, true)
+	}()
+}
+
+// encode returns the encoded responses of batch, caching them as the responses of the single-site requests if cache is set.
+func (merger *merger) encode(batch *mergeBatch, cache bool) (map[int][]byte, error) {
+	if batch.err != nil {
+		return nil, batch.err
+	}
+
+	bodies := map[int][]byte{}
+
+	for siteId, response := range batch.responses {
+		bytes, err := json.Marshal(response)
+
+		if err != nil {
+			return nil, err
+		}
+
+		bodies[siteId] = bytes
+
+		if cache {
+			request := batch.call.Request
+			request.PathParams = maps.Clone(request.PathParams)
+			request.PathParams["siteId"] = strconv.Itoa(siteId)
+			merger.client.store(request, bytes)
+		}
+	}
+
+	return bodies, nil
+}
+
+func mergeDataPeriod(ctx context.Context, client *Client, params any, siteIds []int) (map[int]any, error) {
+	response, err := client.GetSiteDataStartAndEndDatesBulk(ctx, SiteDataStartAndEndDatesBulkParams{SiteIds: siteIds})
+	responses := map[int]any{}
+
+	for _, period := range response.DataPeriodList.SiteEnergyList {
+		responses[period.Id] = SiteDataPeriodResponse{DataPeriod: DataPeriod{StartDate: period.StartDate, EndDate: period.EndDate}}
+	}
+
+	return responses, err
+}
+
+func mergeEnergy(ctx context.Context, client *Client, params any, siteIds []int) (map[int]any, error) {
+	single := params.(SiteEnergyParams)
+	response, err := client.GetSiteEnergyBulk(ctx, SiteEnergyBulkParams{SiteIds: siteIds, StartDate: single.StartDate, EndDate: single.EndDate, TimeUnit: single.TimeUnit})
+	responses := map[int]any{}
+	energy := response.SitesEnergy
+
+	for _, site := range energy.SiteEnergyList {
+		values := site.EnergyValues
+		responses[site.SiteId] = SiteEnergyResponse{Energy: SiteEnergy{TimeUnit: energy.TimeUnit, Unit: energy.Unit, MeasuredBy: values.MeasuredBy, Values: values.Values}}
+	}
+
+	return responses, err
+}
+
+func mergeTimeFrameEnergy(ctx context.Context, client *Client, params any, siteIds []int) (map[int]any, error) {
+	single := params.(SiteEnergyTimePeriodParams)
+	response, err := client.GetSiteEnergyTimePeriodBulk(ctx, SiteEnergyTimePeriodBulkParams{SiteIds: siteIds, StartDate: single.StartDate, EndDate: single.EndDate})
+	responses := map[int]any{}
+
+	for _, site := range response.TimeFrameEnergyList.TimeFrameEnergyList {
+		responses[site.SiteId] = SiteEnergyTimePeriodResponse{TimeFrameEnergy: site.TimeFrameEnergy}
+	}
+
+	return responses, err
+}
+
+func mergePower(ctx context.Context, client *Client, params any, siteIds []int) (map[int]any, error) {
+	single := params.(SitePowerParams)
+	response, err := client.GetSitePowerBulk(ctx, SitePowerBulkParams{SiteIds: siteIds, StartTime: single.StartTime, EndTime: single.EndTime})
+	responses := map[int]any{}
+	power := response.PowerDateValuesList
+
+	for _, site := range power.SiteEnergyList {
+		values := site.PowerDataValueSeries
+		responses[site.SiteId] = SitePowerResponse{Power: SitePower{TimeUnit: power.TimeUnit, Unit: power.Unit, MeasuredBy: values.MeasuredBy, Values: values.Values}}
+	}
+
+	return responses, err
+}
+
+func mergeOverview(ctx context.Context, client *Client, params any, siteIds []int) (map[int]any, error) {
+	response, err := client.GetSiteOverviewBulk(ctx, SiteOverviewBulkParams{SiteIds: siteIds})
+	responses := map[int]any{}
+
+	for _, site := range response.SitesOverviews.SiteEnergyList {
+		responses[site.SiteId] = SiteOverviewResponse{Overview: site.SiteOverview}
+	}
+
+	return responses, err
+}
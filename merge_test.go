@@ -0,0 +1,179 @@
+package golaredge
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestClientBulkMerging checks that the calls of a window are merged into one bulk request per set of parameters
+// and that every call receives the response of its site.
+func TestClientBulkMerging(t *testing.T) {
+	mutex := sync.Mutex{}
+	paths := []string{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		paths = append(paths, r.URL.Path)
+		mutex.Unlock()
+
+		switch r.URL.Path {
+		case "/sites/1,2,3/overview":
+			w.Write([]byte(`{"sitesOverviews":{"count":2,"siteEnergyList":[
+				{"siteId":1,"siteOverview":{"currentPower":{"power":100}}},
+				{"siteId":2,"siteOverview":{"currentPower":{"power":200}}}]}}`))
+		case "/site/4/overview":
+			w.Write([]byte(`{"overview":{"currentPower":{"power":400}}}`))
+		case "/sites/1,2/energy":
+			w.Write([]byte(`{"sitesEnergy":{"timeUnit":"DAY","unit":"Wh","count":2,"siteEnergyList":[
+				{"siteId":1,"energyValues":{"measuredBy":"INVERTER","values":[{"date":"2024-06-01 00:00:00","value":10}]}},
+				{"siteId":2,"energyValues":{"measuredBy":"METER","values":[{"date":"2024-06-01 00:00:00","value":20}]}}]}}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+		}
+	})
+	WithBulkMerging(200 * time.Millisecond)(client)
+
+	start := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	overview := func(siteId int) (float64, error) {
+		response, err := client.GetSiteOverview(t.Context(), SiteOverviewParams{SiteId: siteId})
+
+		return response.Overview.CurrentPower.Power, err
+	}
+	energy := func(siteId int) (float64, error) {
+		response, err := client.GetSiteEnergy(t.Context(), SiteEnergyParams{SiteId: siteId, StartDate: start, EndDate: start, TimeUnit: TimeUnitDay})
+
+		if err != nil || response.Energy.Unit != "Wh" || len(response.Energy.Values) != 1 {
+			return 0, err
+		}
+
+		return *response.Energy.Values[0].Value, nil
+	}
+
+	type mergedCall struct {
+		siteId int
+		call   func(siteId int) (float64, error)
+	}
+
+	calls := []mergedCall{{1, overview}, {2, overview}, {3, overview}, {1, overview}, {1, energy}, {2, energy}}
+
+	values := make([]float64, len(calls))
+	errs := make([]error, len(calls))
+	wait := sync.WaitGroup{}
+
+	for i := range calls {
+		wait.Add(1)
+
+		go func() {
+			defer wait.Done()
+
+			values[i], errs[i] = calls[i].call(calls[i].siteId)
+		}()
+
+		// the bulk site ids keep the order of the calls
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	wait.Wait()
+
+	if want := []float64{100, 200, 0, 100, 10, 20}; !slices.Equal(values, want) {
+		t.Errorf("values = %v, want %v", values, want)
+	}
+
+	if !errors.Is(errs[2], ErrSiteNotInResponse) || errs[0] != nil || errs[4] != nil {
+		t.Errorf("errors = %v, want ErrSiteNotInResponse for site 3 only", errs)
+	}
+
+	if power, err := overview(4); err != nil || power != 400 {
+		t.Errorf("got %v, %v", power, err)
+	}
+
+	slices.Sort(paths)
+
+	if want := []string{"/site/4/overview", "/sites/1,2,3/overview", "/sites/1,2/energy"}; !slices.Equal(paths, want) {
+		t.Errorf("requests = %q, want %q", paths, want)
+	}
+
+	if stats := client.MergingStats(); stats.Calls != 7 || stats.Requests != 3 {
+		t.Errorf("got %+v", stats)
+	}
+}
+
+// TestClientBulkMergingCache checks that every call of a site receives its own copy of the response, that the parts of a bulk
+// response are cached as the responses of their sites and that cached calls do not wait for the window.
+func TestClientBulkMergingCache(t *testing.T) {
+	requests := 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		if r.URL.Path != "/sites/1,2/energy" && r.URL.Path != "/sites/2,1/energy" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+
+		w.Write([]byte(`{"sitesEnergy":{"timeUnit":"DAY","unit":"Wh","count":2,"siteEnergyList":[
+			{"siteId":1,"energyValues":{"measuredBy":"INVERTER","values":[{"date":"2024-06-01 00:00:00","value":10}]}},
+			{"siteId":2,"energyValues":{"measuredBy":"METER","values":[{"date":"2024-06-01 00:00:00","value":20}]}}]}}`))
+	})
+	losAngeles, err := time.LoadLocation("America/Los_Angeles")
+
+	if err != nil {
+		t.Skip(err)
+	}
+
+	client.SetSiteTimeZone(1, losAngeles)
+	WithCache(NewMemoryCache(10), nil)(client)
+	WithBulkMerging(200 * time.Millisecond)(client)
+
+	// June 1 in Los Angeles and in UTC
+	start := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	params := func(siteId int) SiteEnergyParams {
+		return SiteEnergyParams{SiteId: siteId, StartDate: start, EndDate: start, TimeUnit: TimeUnitDay}
+	}
+	responses := make([]SiteEnergyResponse, 3)
+	wait := sync.WaitGroup{}
+
+	// site 1 is called twice, both calls localize their response
+	for i, siteId := range []int{1, 1, 2} {
+		wait.Add(1)
+
+		go func() {
+			defer wait.Done()
+
+			var err error
+
+			if responses[i], err = client.GetSiteEnergy(t.Context(), params(siteId)); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+
+	wait.Wait()
+
+	for i, response := range responses {
+		if len(response.Energy.Values) != 1 {
+			t.Fatalf("response %d = %+v", i, response)
+		}
+	}
+
+	if responses[0].Energy.Values[0].Date.Location() != losAngeles || responses[2].Energy.Values[0].Date.Location() == losAngeles {
+		t.Errorf("got %+v", responses)
+	}
+
+	begin := time.Now()
+
+	for _, siteId := range []int{1, 2} {
+		if _, err := client.GetSiteEnergy(t.Context(), params(siteId)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if elapsed := time.Since(begin); elapsed >= 200*time.Millisecond {
+		t.Errorf("cached calls took %s, want them not to wait for the window", elapsed)
+	}
+
+	if stats := client.MergingStats(); requests != 1 || stats.Calls != 5 || stats.Requests != 1 {
+		t.Errorf("got %d requests, %+v", requests, stats)
+	}
+}
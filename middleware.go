@@ -0,0 +1,172 @@
+package golaredge
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Call is a call of an endpoint method of the client, as seen by the middlewares.
+type Call struct {
+	// Request is the validated request of the call, its Endpoint identifies the endpoint independent of the site.
+	Request Request
+
+	// Params are the params passed to the method, with their times in the time zone of the site. They are nil for the version endpoints.
+	Params any
+}
+
+// Handler executes a call and returns its decoded response, e.g. a SiteOverviewResponse for EndpointSiteOverview or the []byte of an image.
+type Handler func(ctx context.Context, call Call) (any, error)
+
+// Middleware wraps the handler executing the calls of a client, e.g. to measure, log or short-circuit them.
+type Middleware func(next Handler) Handler
+
+// WithMiddleware adds middlewares around the execution of every call. They run in the order they are added, over all WithMiddleware
+// options: the first one is the outermost, it sees the call first and its response last.
+// Middlewares run after the validation of the params, a call with invalid params never reaches them, and before the cache,
+// the retries, the rate limiter and the logger of WithLogger, so a call goes through them once even if it is retried or cached.
+func WithMiddleware(middlewares ...Middleware) Option {
+	return func(client *Client) {
+		client.middlewares = append(client.middlewares, middlewares...)
+	}
+}
+
+// execute executes request built from params through the middlewares of the client, decode turns the response body into T.
+// err is the error returned by the builder, a request that failed to build is not executed.
+func execute[T any](ctx context.Context, client *Client, request Request, params any, err error, decode func(bytes []byte) (T, error)) (T, error) {
+	var result T
+
+	if err != nil {
+		return result, err
+	}
+
+	handler := Handler(func(ctx context.Context, call Call) (any, error) {
+		bytes, err := client.get(ctx, call.Request)
+
+		if err != nil {
+			return nil, err
+		}
+
+		response, err := decode(bytes)
+
+		return response, err
+	})
+
+	for i := len(client.middlewares) - 1; i >= 0; i-- {
+		handler = client.middlewares[i](handler)
+	}
+
+	response, err := handler(ctx, Call{Request: request, Params: params})
+
+	if response == nil {
+		return result, err
+	}
+
+	result, ok := response.(T)
+
+	if !ok {
+		return result, fmt.Errorf("a middleware returned a %T for %s, want %T", response, request.Endpoint, result)
+	}
+
+	return result, err
+}
+
+// TimeoutMiddleware cancels every call that takes longer than timeout, including its retries and the wait for the rate limiter.
+func TimeoutMiddleware(timeout time.Duration) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, call Call) (any, error) {
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+
+			return next(ctx, call)
+		}
+	}
+}
+
+// LoggingMiddleware logs every call to logger, at debug level or at warn level if it fails.
+// Unlike WithLogger, it logs a call once and not every request sent for it.
+func LoggingMiddleware(logger *slog.Logger) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, call Call) (any, error) {
+			start := time.Now()
+			response, err := next(ctx, call)
+			attributes := []slog.Attr{
+				slog.String("endpoint", string(call.Request.Endpoint)),
+				slog.String("request", call.Request.Canonical()),
+				slog.Duration("duration", time.Since(start)),
+			}
+
+			if err != nil {
+				logger.LogAttrs(ctx, slog.LevelWarn, "golaredge call failed", append(attributes, slog.String("error", err.Error()))...)
+			} else {
+				logger.LogAttrs(ctx, slog.LevelDebug, "golaredge call", attributes...)
+			}
+
+			return response, err
+		}
+	}
+}
+
+// EndpointMetrics are the metrics of the calls of an endpoint.
+type EndpointMetrics struct {
+	Calls  int
+	Errors int
+
+	// Duration is the total duration of the calls.
+	Duration time.Duration
+}
+
+// Metrics counts the calls of every endpoint going through its middleware, see Metrics.Middleware.
+type Metrics struct {
+	mutex     sync.Mutex
+	endpoints map[Endpoint]EndpointMetrics
+}
+
+func NewMetrics() *Metrics {
+	return &Metrics{endpoints: map[Endpoint]EndpointMetrics{}}
+}
+
+// Middleware returns the middleware recording the calls in metrics. It can be shared by several clients.
+func (metrics *Metrics) Middleware() Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, call Call) (any, error) {
+			start := time.Now()
+			response, err := next(ctx, call)
+
+			metrics.record(call.Request.Endpoint, time.Since(start), err)
+
+			return response, err
+		}
+	}
+}
+
+// Endpoints returns the metrics of every endpoint called so far.
+func (metrics *Metrics) Endpoints() map[Endpoint]EndpointMetrics {
+	metrics.mutex.Lock()
+	defer metrics.mutex.Unlock()
+
+	endpoints := make(map[Endpoint]EndpointMetrics, len(metrics.endpoints))
+
+	for endpoint, endpointMetrics := range metrics.endpoints {
+		endpoints[endpoint] = endpointMetrics
+	}
+
+	return endpoints
+}
+
+func (metrics *Metrics) record(endpoint Endpoint, duration time.Duration, err error) {
+	metrics.mutex.Lock()
+	defer metrics.mutex.Unlock()
+
+	endpointMetrics := metrics.endpoints[endpoint]
+	endpointMetrics.Calls++
+	endpointMetrics.Duration += duration
+
+	if err != nil {
+		endpointMetrics.Errors++
+	}
+
+	metrics.endpoints[endpoint] = endpointMetrics
+}
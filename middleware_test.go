@@ -0,0 +1,104 @@
+package golaredge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+)
+
+// TestMiddlewareOrder checks that the middlewares run in the order they are added and see the call and its decoded response.
+func TestMiddlewareOrder(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"overview":{"currentPower":{"power":42}}}`))
+	})
+	trace := []string{}
+	tracing := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(ctx context.Context, call Call) (any, error) {
+				trace = append(trace, name+" "+call.Request.Canonical())
+				response, err := next(ctx, call)
+
+				if overview, ok := response.(SiteOverviewResponse); ok && call.Params.(SiteOverviewParams).SiteId == 1 {
+					trace = append(trace, fmt.Sprintf("%s done %v", name, overview.Overview.CurrentPower.Power))
+				}
+
+				return response, err
+			}
+		}
+	}
+
+	WithMiddleware(tracing("outer"))(client)
+	WithMiddleware(tracing("inner"))(client)
+
+	if _, err := client.GetSiteOverview(t.Context(), SiteOverviewParams{SiteId: 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"outer site/1/overview", "inner site/1/overview", "inner done 42", "outer done 42"}
+
+	if !slices.Equal(trace, want) {
+		t.Errorf("trace = %q, want %q", trace, want)
+	}
+
+	trace = trace[:0]
+
+	if _, err := client.GetSiteOverview(t.Context(), SiteOverviewParams{SiteId: -1}); err == nil || len(trace) != 0 {
+		t.Errorf("error = %v, trace = %q, want invalid params to fail before the middlewares", err, trace)
+	}
+}
+
+// TestMiddlewareShortCircuit checks that a middleware can answer a call without a request and that a wrong response type fails the call.
+func TestMiddlewareShortCircuit(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s", r.URL)
+	})
+	response := any(SitePowerFlowResponse{SiteCurrentPowerFlow: SitePowerFlow{Unit: "kW"}})
+
+	WithMiddleware(func(next Handler) Handler {
+		return func(ctx context.Context, call Call) (any, error) {
+			return response, nil
+		}
+	})(client)
+
+	if flow, err := client.GetSitePowerFlow(t.Context(), SitePowerFlowParams{SiteId: 1}); err != nil || flow.SiteCurrentPowerFlow.Unit != "kW" {
+		t.Errorf("got %+v, %v", flow, err)
+	}
+
+	response = SiteOverviewResponse{}
+
+	if _, err := client.GetSitePowerFlow(t.Context(), SitePowerFlowParams{SiteId: 1}); err == nil {
+		t.Error("error = nil, want an error for a response of the wrong type")
+	}
+}
+
+// TestBuiltinMiddlewares checks the metrics and the timeout middleware.
+func TestBuiltinMiddlewares(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/site/2/overview" {
+			<-r.Context().Done()
+
+			return
+		}
+
+		w.Write([]byte(`{"overview":{}}`))
+	})
+	metrics := NewMetrics()
+
+	WithMiddleware(metrics.Middleware(), TimeoutMiddleware(50*time.Millisecond))(client)
+
+	if _, err := client.GetSiteOverview(t.Context(), SiteOverviewParams{SiteId: 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.GetSiteOverview(t.Context(), SiteOverviewParams{SiteId: 2}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("error = %v, want context.DeadlineExceeded", err)
+	}
+
+	if overview := metrics.Endpoints()[EndpointSiteOverview]; overview.Calls != 2 || overview.Errors != 1 || overview.Duration < 50*time.Millisecond {
+		t.Errorf("got %+v", overview)
+	}
+}
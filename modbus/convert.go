@@ -0,0 +1,157 @@
+package modbus
+
+import (
+	"strings"
+	"time"
+
+	golaredge "github.com/Adrigorithm/GolarEdge"
+)
+
+// Mode returns the status as the inverter mode of the Monitoring API (InverterTelemetry.InverterMode), e.g. "MPPT".
+func (status InverterStatus) Mode() string {
+	switch status {
+	case InverterStatusOff:
+		return "OFF"
+	case InverterStatusSleeping:
+		return "SLEEPING"
+	case InverterStatusStarting:
+		return "STARTING"
+	case InverterStatusProducing:
+		return "MPPT"
+	case InverterStatusThrottled:
+		return "THROTTLED"
+	case InverterStatusShuttingDown:
+		return "SHUTTING_DOWN"
+	case InverterStatusFault:
+		return "FAULT"
+	case InverterStatusStandby:
+		return "STANDBY"
+	default:
+		return ""
+	}
+}
+
+// Telemetry returns the inverter as a sample of the inverter technical data of the Monitoring API, measured at date.
+// The phase data of a single phase inverter only has L1Data, the line voltages are only set for three phase inverters.
+func (inverter Inverter) Telemetry(date time.Time) golaredge.InverterTelemetry {
+	telemetry := golaredge.InverterTelemetry{
+		Date:             golaredge.Timestamp{Time: date},
+		TotalActivePower: inverter.AcPower,
+		DcVoltage:        inverter.DcVoltage,
+		TotalEnergy:      inverter.LifetimeEnergy,
+		Temperature:      inverter.Temperature,
+		InverterMode:     inverter.Status.Mode(),
+		L1Data:           inverter.phase(inverter.AcCurrentA, inverter.AcVoltageAN),
+	}
+
+	if inverter.ModelId == 101 {
+		telemetry.L1Data = inverter.phase(inverter.AcCurrent, inverter.AcVoltageAN)
+
+		return telemetry
+	}
+
+	l2Data := inverter.phase(inverter.AcCurrentB, inverter.AcVoltageBN)
+	telemetry.L2Data = &l2Data
+
+	if inverter.ModelId == 103 {
+		l3Data := inverter.phase(inverter.AcCurrentC, inverter.AcVoltageCN)
+		telemetry.L3Data = &l3Data
+		telemetry.VL1To2 = &inverter.AcVoltageAB
+		telemetry.VL2To3 = &inverter.AcVoltageBC
+		telemetry.VL3To1 = &inverter.AcVoltageCA
+	}
+
+	return telemetry
+}
+
+// phase returns the data of a single phase. SunSpec only reports the powers of the whole inverter, they are split by the current of the phase.
+func (inverter Inverter) phase(current float64, voltage float64) golaredge.InverterPhaseData {
+	share := 1.0
+
+	if inverter.AcCurrent != 0 {
+		share = current / inverter.AcCurrent
+	}
+
+	return golaredge.InverterPhaseData{
+		AcCurrent:     current,
+		AcVoltage:     voltage,
+		AcFrequency:   inverter.AcFrequency,
+		ApparentPower: inverter.ApparentPower * share,
+		ActivePower:   inverter.AcPower * share,
+		ReactivePower: inverter.ReactivePower * share,
+		CosPhi:        inverter.PowerFactor / 100,
+	}
+}
+
+// Energy returns the lifetime energy readings of the meter, as the meters data of the Monitoring API measured at date:
+// a "FeedIn" meter with the exported energy and a "Purchased" meter with the imported energy.
+func (meter Meter) Energy(date time.Time) []golaredge.MeterEnergy {
+	reading := func(meterType string, energy float64) golaredge.MeterEnergy {
+		return golaredge.MeterEnergy{
+			MeterSerialNumber: meter.SerialNumber,
+			Model:             meter.Model,
+			MeterType:         meterType,
+			Values:            []golaredge.DateValue{{Date: golaredge.Timestamp{Time: date}, Value: &energy}},
+		}
+	}
+
+	return []golaredge.MeterEnergy{reading("FeedIn", meter.ExportedEnergy), reading("Purchased", meter.ImportedEnergy)}
+}
+
+// gridMeter returns the meter measuring the connection to the grid: the one installed as "Export+Import", or else the first meter.
+func (device Device) gridMeter() (Meter, bool) {
+	for i := range device.Meters {
+		if strings.Contains(device.Meters[i].Options, "Export+Import") {
+			return device.Meters[i], true
+		}
+	}
+
+	if len(device.Meters) == 0 {
+		return Meter{}, false
+	}
+
+	return device.Meters[0], true
+}
+
+// PowerFlow returns the current power flow of the device, as the site power flow of the Monitoring API, in kW.
+// Without a meter only the production is known, so the power flow has no grid and the load is the production.
+func (device Device) PowerFlow() golaredge.SitePowerFlow {
+	// the powers are summed in W, as converting each to kW first adds rounding errors
+	production := max(device.Inverter.AcPower, 0)
+	flow := golaredge.SitePowerFlow{
+		Unit: "kW",
+		PV:   &golaredge.PowerFlowElement{Status: elementStatus(production), CurrentPower: production / 1000},
+	}
+
+	meter, ok := device.gridMeter()
+	grid := 0.0
+
+	if ok {
+		grid = meter.Power
+		flow.Grid = &golaredge.PowerFlowElement{Status: elementStatus(grid), CurrentPower: max(grid, -grid) / 1000}
+	}
+
+	load := max(production+grid, 0)
+	flow.Load = &golaredge.PowerFlowElement{Status: elementStatus(load), CurrentPower: load / 1000}
+
+	if production > 0 {
+		flow.Connections = append(flow.Connections, golaredge.PowerFlowConnection{From: "PV", To: "Load"})
+	}
+
+	switch {
+	case grid > 0:
+		flow.Connections = append(flow.Connections, golaredge.PowerFlowConnection{From: "GRID", To: "Load"})
+	case grid < 0:
+		flow.Connections = append(flow.Connections, golaredge.PowerFlowConnection{From: "LOAD", To: "Grid"})
+	}
+
+	return flow
+}
+
+func elementStatus(power float64) string {
+	if power == 0 {
+		return "Idle"
+	}
+
+	return "Active"
+}
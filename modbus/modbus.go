@@ -0,0 +1,220 @@
+// Package modbus reads SolarEdge inverters and meters locally over Modbus TCP, using the SunSpec information model.
+// Unlike the Monitoring API it has no daily quota, so inverters can be polled every few seconds. The readings convert to the
+// structures of the Monitoring API (see Device.PowerFlow, Inverter.Telemetry and Meter.Energy), so both sources are interchangeable.
+// Modbus TCP has to be enabled on the inverter (SetApp or the display), SolarEdge uses port 1502 by default.
+package modbus
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultPort is the Modbus TCP port SolarEdge inverters listen on by default.
+	DefaultPort int = 1502
+
+	// DefaultTimeout is the time a request waits for the response of the inverter.
+	DefaultTimeout time.Duration = 5 * time.Second
+
+	// DefaultUnitId is the modbus id SolarEdge inverters answer to by default.
+	DefaultUnitId byte = 1
+
+	readHoldingRegisters byte = 0x03
+	exceptionFlag        byte = 0x80
+
+	// maxRegisters is the maximum number of registers a single read holding registers request can return.
+	maxRegisters uint16 = 125
+
+	mbapHeaderLength int = 7
+)
+
+var (
+	ErrInvalidResponse = errors.New("invalid modbus response")
+	ErrNotSunSpec      = errors.New("device does not implement sunspec")
+)
+
+// ExceptionError is returned when the device answers a request with a modbus exception.
+type ExceptionError struct {
+	FunctionCode byte
+	Code         byte
+}
+
+func (exception *ExceptionError) Error() string {
+	switch exception.Code {
+	case 1:
+		return fmt.Sprintf("modbus exception %d (illegal function) for function %d", exception.Code, exception.FunctionCode)
+	case 2:
+		return fmt.Sprintf("modbus exception %d (illegal data address) for function %d", exception.Code, exception.FunctionCode)
+	case 3:
+		return fmt.Sprintf("modbus exception %d (illegal data value) for function %d", exception.Code, exception.FunctionCode)
+	default:
+		return fmt.Sprintf("modbus exception %d for function %d", exception.Code, exception.FunctionCode)
+	}
+}
+
+// Client is a Modbus TCP connection to a single device. It is safe for concurrent use, the requests are executed one at a time.
+// A request failing with anything but a modbus exception drops the connection, the next request dials the device again.
+type Client struct {
+	address string
+	unitId  byte
+	timeout time.Duration
+
+	mutex         sync.Mutex
+	conn          net.Conn
+	closed        bool
+	transactionId uint16
+}
+
+// Dial connects to the inverter at address (e.g. "192.168.1.10:1502"). unitId is the modbus id of the inverter,
+// 0 (the broadcast id, which devices do not answer) selects DefaultUnitId.
+func Dial(address string, unitId byte) (*Client, error) {
+	conn, err := net.DialTimeout("tcp", address, DefaultTimeout)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if unitId == 0 {
+		unitId = DefaultUnitId
+	}
+
+	return &Client{address: address, conn: conn, unitId: unitId, timeout: DefaultTimeout}, nil
+}
+
+// SetTimeout changes the time a request waits for the response of the device.
+func (client *Client) SetTimeout(timeout time.Duration) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+
+	client.timeout = timeout
+}
+
+func (client *Client) Close() error {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+
+	client.closed = true
+
+	if client.conn == nil {
+		return nil
+	}
+
+	err := client.conn.Close()
+	client.conn = nil
+
+	return err
+}
+
+// ReadHoldingRegisters reads count registers starting at address (0 based, e.g. 40000 for the SunSpec marker).
+// Reads of more than 125 registers are split in several requests.
+func (client *Client) ReadHoldingRegisters(address uint16, count uint16) ([]uint16, error) {
+	registers := make([]uint16, 0, count)
+
+	for count > 0 {
+		chunk := min(count, maxRegisters)
+		values, err := client.readHoldingRegisters(address, chunk)
+
+		if err != nil {
+			return nil, err
+		}
+
+		registers = append(registers, values...)
+		address += chunk
+		count -= chunk
+	}
+
+	return registers, nil
+}
+
+func (client *Client) readHoldingRegisters(address uint16, count uint16) ([]uint16, error) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+
+	if client.closed {
+		return nil, net.ErrClosed
+	}
+
+	if client.conn == nil {
+		conn, err := net.DialTimeout("tcp", client.address, client.timeout)
+
+		if err != nil {
+			return nil, err
+		}
+
+		client.conn = conn
+	}
+
+	registers, err := client.exchange(address, count)
+
+	var exception *ExceptionError
+
+	if err != nil && !errors.As(err, &exception) {
+		// the late response of a timed out request or the rest of a partially read frame would be read as the response of
+		// the next request, so the connection is replaced
+		client.conn.Close()
+		client.conn = nil
+	}
+
+	return registers, err
+}
+
+// exchange sends a read holding registers request on the connection and reads its response.
+func (client *Client) exchange(address uint16, count uint16) ([]uint16, error) {
+	client.transactionId++
+
+	request := make([]byte, mbapHeaderLength+5)
+	binary.BigEndian.PutUint16(request[0:], client.transactionId)
+	binary.BigEndian.PutUint16(request[2:], 0)
+	binary.BigEndian.PutUint16(request[4:], 6)
+	request[6] = client.unitId
+	request[7] = readHoldingRegisters
+	binary.BigEndian.PutUint16(request[8:], address)
+	binary.BigEndian.PutUint16(request[10:], count)
+
+	if err := client.conn.SetDeadline(time.Now().Add(client.timeout)); err != nil {
+		return nil, err
+	}
+
+	if _, err := client.conn.Write(request); err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, mbapHeaderLength)
+
+	if _, err := io.ReadFull(client.conn, header); err != nil {
+		return nil, err
+	}
+
+	length := int(binary.BigEndian.Uint16(header[4:]))
+
+	if binary.BigEndian.Uint16(header[0:]) != client.transactionId || binary.BigEndian.Uint16(header[2:]) != 0 || length < 3 {
+		return nil, fmt.Errorf("%w: unexpected header %x", ErrInvalidResponse, header)
+	}
+
+	pdu := make([]byte, length-1)
+
+	if _, err := io.ReadFull(client.conn, pdu); err != nil {
+		return nil, err
+	}
+
+	if pdu[0] == readHoldingRegisters|exceptionFlag {
+		return nil, &ExceptionError{FunctionCode: readHoldingRegisters, Code: pdu[1]}
+	}
+
+	if pdu[0] != readHoldingRegisters || int(pdu[1]) != int(count)*2 || len(pdu) != 2+int(count)*2 {
+		return nil, fmt.Errorf("%w: unexpected pdu of %d bytes for %d registers", ErrInvalidResponse, len(pdu), count)
+	}
+
+	registers := make([]uint16, count)
+
+	for i := range registers {
+		registers[i] = binary.BigEndian.Uint16(pdu[2+i*2:])
+	}
+
+	return registers, nil
+}
@@ -0,0 +1,277 @@
+package modbus
+
+import (
+	"errors"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	golaredge "github.com/Adrigorithm/GolarEdge"
+)
+
+func testDevice() Device {
+	return Device{
+		Inverter: Inverter{
+			Common: Common{
+				Manufacturer:  "SolarEdge",
+				Model:         "SE5K-RW0TEBEN4",
+				Version:       "0004.0018.0518",
+				SerialNumber:  "7E1A2B3C",
+				DeviceAddress: 1,
+			},
+			ModelId:        103,
+			AcCurrent:      7.25,
+			AcCurrentA:     2.42,
+			AcCurrentB:     2.41,
+			AcCurrentC:     2.42,
+			AcVoltageAB:    400.1,
+			AcVoltageBC:    399.8,
+			AcVoltageCA:    401.2,
+			AcVoltageAN:    231.1,
+			AcVoltageBN:    230.4,
+			AcVoltageCN:    231.7,
+			AcPower:        1655,
+			AcFrequency:    50.01,
+			ApparentPower:  1680,
+			ReactivePower:  -290,
+			PowerFactor:    98.5,
+			LifetimeEnergy: 12345678,
+			DcCurrent:      2.14,
+			DcVoltage:      781.3,
+			DcPower:        1672,
+			Temperature:    41.37,
+			Status:         InverterStatusProducing,
+		},
+		Meters: []Meter{
+			{
+				Common: Common{
+					Manufacturer:  "WattNode",
+					Model:         "WNC-3Y-400-MB",
+					Options:       "Export+Import",
+					SerialNumber:  "4012345",
+					DeviceAddress: 2,
+				},
+				ModelId:        203,
+				AcCurrent:      -4.2,
+				VoltageLN:      230.9,
+				Frequency:      50.01,
+				Power:          -1203,
+				PowerA:         -401,
+				PowerB:         -400,
+				PowerC:         -402,
+				PowerFactor:    -97.2,
+				ExportedEnergy: 8765432,
+				ImportedEnergy: 2345678,
+			},
+		},
+	}
+}
+
+func newTestClient(t *testing.T, device Device) (*Client, *Simulator) {
+	t.Helper()
+
+	simulator, err := NewSimulator(device, 1)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { simulator.Close() })
+
+	client, err := Dial(simulator.Address(), 1)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { client.Close() })
+
+	return client, simulator
+}
+
+func TestReadDevice(t *testing.T) {
+	device := testDevice()
+	client, _ := newTestClient(t, device)
+
+	result, err := client.ReadDevice()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(result, device) {
+		t.Errorf("got %+v, want %+v", result, device)
+	}
+}
+
+func TestDialDefaultUnitId(t *testing.T) {
+	simulator, err := NewSimulator(testDevice(), DefaultUnitId)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { simulator.Close() })
+
+	client, err := Dial(simulator.Address(), 0)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { client.Close() })
+	client.SetTimeout(time.Second)
+
+	if _, err := client.ReadDevice(); err != nil {
+		t.Errorf("ReadDevice() error = %v, want the default unit id to be used", err)
+	}
+}
+
+func TestReadDeviceUpdates(t *testing.T) {
+	device := testDevice()
+	client, simulator := newTestClient(t, device)
+
+	device.Inverter.AcPower = 0
+	device.Inverter.Status = InverterStatusSleeping
+	simulator.SetDevice(device)
+
+	inverter, err := client.ReadInverter()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if inverter.AcPower != 0 || inverter.Status != InverterStatusSleeping {
+		t.Errorf("got power %v and status %v, want 0 and sleeping", inverter.AcPower, inverter.Status)
+	}
+}
+
+func TestTimeoutRecovery(t *testing.T) {
+	device := testDevice()
+	client, simulator := newTestClient(t, device)
+
+	client.SetTimeout(50 * time.Millisecond)
+	simulator.SetDelay(200 * time.Millisecond)
+
+	var netError net.Error
+
+	if _, err := client.ReadInverter(); !errors.As(err, &netError) || !netError.Timeout() {
+		t.Fatalf("ReadInverter() error = %v, want a timeout", err)
+	}
+
+	simulator.SetDelay(0)
+	client.SetTimeout(time.Second)
+
+	// the late response of the timed out request must not be read as the response of the following ones
+	for range 2 {
+		result, err := client.ReadDevice()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !reflect.DeepEqual(result, device) {
+			t.Errorf("got %+v, want %+v", result, device)
+		}
+	}
+}
+
+func TestNotImplemented(t *testing.T) {
+	client, simulator := newTestClient(t, testDevice())
+
+	// the reactive power of the inverter and its scale factor
+	simulator.SetRegister(SunSpecBase+4+commonLength+2+18, notImplementedInt16)
+	simulator.SetRegister(SunSpecBase+4+commonLength+2+19, notImplementedInt16)
+
+	inverter, err := client.ReadInverter()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if inverter.ReactivePower != 0 {
+		t.Errorf("got reactive power %v, want 0", inverter.ReactivePower)
+	}
+}
+
+func TestNotSunSpec(t *testing.T) {
+	client, simulator := newTestClient(t, testDevice())
+
+	simulator.SetRegister(SunSpecBase, 0)
+
+	if _, err := client.ReadDevice(); !errors.Is(err, ErrNotSunSpec) {
+		t.Errorf("got %v, want %v", err, ErrNotSunSpec)
+	}
+}
+
+func TestException(t *testing.T) {
+	client, _ := newTestClient(t, testDevice())
+
+	_, err := client.ReadHoldingRegisters(100, 2)
+	exception := &ExceptionError{}
+
+	if !errors.As(err, &exception) || exception.Code != 2 {
+		t.Errorf("got %v, want illegal data address", err)
+	}
+}
+
+func TestReadHoldingRegistersChunks(t *testing.T) {
+	client, _ := newTestClient(t, testDevice())
+
+	registers, err := client.ReadHoldingRegisters(SunSpecBase, 250)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(registers) != 250 || registers[0] != sunSpecMarkerHigh || registers[1] != sunSpecMarkerLow {
+		t.Errorf("got %d registers starting with %x, want 250 starting with the marker", len(registers), registers[:2])
+	}
+}
+
+func TestScale(t *testing.T) {
+	tests := []struct {
+		raw    uint16
+		factor int16
+		want   float64
+	}{
+		{raw: 1234, factor: -2, want: 12.34},
+		{raw: 0xfffe, factor: 0, want: -2},
+		{raw: 5, factor: 2, want: 500},
+		{raw: notImplementedInt16, factor: 0, want: 0},
+	}
+
+	for _, test := range tests {
+		if got := scaleInt16(test.raw, encodeFactor(test.factor)); got != test.want {
+			t.Errorf("scaleInt16(%d, %d) = %v, want %v", test.raw, test.factor, got, test.want)
+		}
+	}
+}
+
+func TestPowerFlow(t *testing.T) {
+	flow := testDevice().PowerFlow()
+
+	if flow.PV.CurrentPower != 1.655 || flow.Grid.CurrentPower != 1.203 || flow.Load.CurrentPower != 0.452 {
+		t.Errorf("got pv %v, grid %v and load %v", flow.PV.CurrentPower, flow.Grid.CurrentPower, flow.Load.CurrentPower)
+	}
+
+	want := []golaredge.PowerFlowConnection{{From: "PV", To: "Load"}, {From: "LOAD", To: "Grid"}}
+
+	if !reflect.DeepEqual(flow.Connections, want) {
+		t.Errorf("got connections %v, want %v", flow.Connections, want)
+	}
+}
+
+func TestTelemetry(t *testing.T) {
+	date := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	telemetry := testDevice().Inverter.Telemetry(date)
+
+	if !telemetry.Date.Equal(date) || telemetry.TotalActivePower != 1655 || telemetry.InverterMode != "MPPT" {
+		t.Errorf("got %+v", telemetry)
+	}
+
+	if telemetry.L3Data == nil || telemetry.VL1To2 == nil || *telemetry.VL1To2 != 400.1 {
+		t.Errorf("got no three phase data in %+v", telemetry)
+	}
+}
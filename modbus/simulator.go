@@ -0,0 +1,369 @@
+package modbus
+
+import (
+	"encoding/binary"
+	"io"
+	"math"
+	"net"
+	"sync"
+	"time"
+)
+
+// Scale factors the simulator encodes the values of a device with.
+const (
+	currentFactor     int16 = -2
+	voltageFactor     int16 = -1
+	powerFactor       int16 = 0
+	frequencyFactor   int16 = -2
+	powerFactorFactor int16 = -2
+	energyFactor      int16 = 0
+	temperatureFactor int16 = -2
+)
+
+// Simulator is an in-process Modbus TCP server exposing a Device as a SolarEdge inverter does, for tests without hardware.
+// It only implements read holding registers, reads outside of the SunSpec models return the illegal data address exception.
+type Simulator struct {
+	listener net.Listener
+	unitId   byte
+
+	mutex     sync.RWMutex
+	registers map[uint16]uint16
+	conns     map[net.Conn]bool
+	closed    bool
+	delay     time.Duration
+
+	wait sync.WaitGroup
+}
+
+// NewSimulator starts a simulator of device on a random local port, see Simulator.Address.
+// Requests for a unit id other than unitId are not answered.
+func NewSimulator(device Device, unitId byte) (*Simulator, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+
+	if err != nil {
+		return nil, err
+	}
+
+	simulator := &Simulator{listener: listener, unitId: unitId, conns: map[net.Conn]bool{}}
+	simulator.SetDevice(device)
+
+	simulator.wait.Add(1)
+
+	go simulator.serve()
+
+	return simulator, nil
+}
+
+// Address returns the host:port the simulator listens on, to pass to Dial.
+func (simulator *Simulator) Address() string {
+	return simulator.listener.Addr().String()
+}
+
+// SetDevice replaces the values exposed by the simulator.
+func (simulator *Simulator) SetDevice(device Device) {
+	registers := encodeDevice(device)
+
+	simulator.mutex.Lock()
+	defer simulator.mutex.Unlock()
+
+	simulator.registers = registers
+}
+
+// SetRegister overwrites a single register, e.g. to expose a value that is not implemented.
+func (simulator *Simulator) SetRegister(address uint16, value uint16) {
+	simulator.mutex.Lock()
+	defer simulator.mutex.Unlock()
+
+	simulator.registers[address] = value
+}
+
+// SetDelay delays the following responses by delay, e.g. to test the timeout of a client.
+func (simulator *Simulator) SetDelay(delay time.Duration) {
+	simulator.mutex.Lock()
+	defer simulator.mutex.Unlock()
+
+	simulator.delay = delay
+}
+
+// Close stops the simulator and closes its connections.
+func (simulator *Simulator) Close() error {
+	simulator.mutex.Lock()
+	simulator.closed = true
+
+	for conn := range simulator.conns {
+		conn.Close()
+	}
+
+	simulator.mutex.Unlock()
+
+	err := simulator.listener.Close()
+	simulator.wait.Wait()
+
+	return err
+}
+
+func (simulator *Simulator) serve() {
+	defer simulator.wait.Done()
+
+	for {
+		conn, err := simulator.listener.Accept()
+
+		if err != nil {
+			return
+		}
+
+		simulator.mutex.Lock()
+
+		if simulator.closed {
+			simulator.mutex.Unlock()
+			conn.Close()
+
+			return
+		}
+
+		simulator.conns[conn] = true
+		simulator.mutex.Unlock()
+
+		simulator.wait.Add(1)
+
+		go simulator.handle(conn)
+	}
+}
+
+func (simulator *Simulator) handle(conn net.Conn) {
+	defer simulator.wait.Done()
+	defer func() {
+		simulator.mutex.Lock()
+		delete(simulator.conns, conn)
+		simulator.mutex.Unlock()
+
+		conn.Close()
+	}()
+
+	header := make([]byte, mbapHeaderLength)
+
+	for {
+		if _, err := io.ReadFull(conn, header); err != nil {
+			return
+		}
+
+		length := int(binary.BigEndian.Uint16(header[4:]))
+
+		if length < 2 {
+			return
+		}
+
+		pdu := make([]byte, length-1)
+
+		if _, err := io.ReadFull(conn, pdu); err != nil {
+			return
+		}
+
+		if header[6] != simulator.unitId {
+			continue
+		}
+
+		simulator.mutex.RLock()
+		delay := simulator.delay
+		simulator.mutex.RUnlock()
+
+		time.Sleep(delay)
+
+		response := simulator.respond(pdu)
+		frame := make([]byte, mbapHeaderLength, mbapHeaderLength+len(response))
+		copy(frame, header[:4])
+		binary.BigEndian.PutUint16(frame[4:], uint16(len(response)+1))
+		frame[6] = header[6]
+
+		if _, err := conn.Write(append(frame, response...)); err != nil {
+			return
+		}
+	}
+}
+
+// respond returns the response pdu to a request pdu.
+func (simulator *Simulator) respond(pdu []byte) []byte {
+	if pdu[0] != readHoldingRegisters {
+		return []byte{pdu[0] | exceptionFlag, 1}
+	}
+
+	if len(pdu) != 5 {
+		return []byte{pdu[0] | exceptionFlag, 3}
+	}
+
+	address := binary.BigEndian.Uint16(pdu[1:])
+	count := binary.BigEndian.Uint16(pdu[3:])
+
+	if count == 0 || count > maxRegisters {
+		return []byte{pdu[0] | exceptionFlag, 3}
+	}
+
+	simulator.mutex.RLock()
+	defer simulator.mutex.RUnlock()
+
+	response := []byte{pdu[0], byte(count * 2)}
+
+	for i := range count {
+		value, ok := simulator.registers[address+i]
+
+		if !ok {
+			return []byte{pdu[0] | exceptionFlag, 2}
+		}
+
+		response = binary.BigEndian.AppendUint16(response, value)
+	}
+
+	return response
+}
+
+// encodeDevice lays out the models of device as a SolarEdge inverter does: the common and inverter models, followed by a
+// common and meter model per meter, and the end model.
+func encodeDevice(device Device) map[uint16]uint16 {
+	registers := map[uint16]uint16{}
+	address := SunSpecBase
+
+	write := func(values ...uint16) {
+		for _, value := range values {
+			registers[address] = value
+			address++
+		}
+	}
+
+	write(sunSpecMarkerHigh, sunSpecMarkerLow)
+	write(commonModelId, commonLength)
+	write(encodeCommon(device.Inverter.Common)...)
+	write(uint16(device.Inverter.ModelId), inverterLength)
+	write(encodeInverter(device.Inverter)...)
+
+	for i := range device.Meters {
+		meter := device.Meters[i]
+
+		write(commonModelId, commonLength)
+		write(encodeCommon(meter.Common)...)
+		write(uint16(meter.ModelId), meterLength)
+		write(encodeMeter(meter)...)
+	}
+
+	write(endModelId, 0)
+
+	return registers
+}
+
+func encodeCommon(common Common) []uint16 {
+	block := make([]uint16, commonLength)
+
+	encodeString(block[0:16], common.Manufacturer)
+	encodeString(block[16:32], common.Model)
+	encodeString(block[32:40], common.Options)
+	encodeString(block[40:48], common.Version)
+	encodeString(block[48:64], common.SerialNumber)
+	block[64] = uint16(common.DeviceAddress)
+
+	return block
+}
+
+func encodeInverter(inverter Inverter) []uint16 {
+	block := make([]uint16, inverterLength)
+
+	block[0] = unscale(inverter.AcCurrent, currentFactor)
+	block[1] = unscale(inverter.AcCurrentA, currentFactor)
+	block[2] = unscale(inverter.AcCurrentB, currentFactor)
+	block[3] = unscale(inverter.AcCurrentC, currentFactor)
+	block[4] = encodeFactor(currentFactor)
+	block[5] = unscale(inverter.AcVoltageAB, voltageFactor)
+	block[6] = unscale(inverter.AcVoltageBC, voltageFactor)
+	block[7] = unscale(inverter.AcVoltageCA, voltageFactor)
+	block[8] = unscale(inverter.AcVoltageAN, voltageFactor)
+	block[9] = unscale(inverter.AcVoltageBN, voltageFactor)
+	block[10] = unscale(inverter.AcVoltageCN, voltageFactor)
+	block[11] = encodeFactor(voltageFactor)
+	block[12] = unscale(inverter.AcPower, powerFactor)
+	block[13] = encodeFactor(powerFactor)
+	block[14] = unscale(inverter.AcFrequency, frequencyFactor)
+	block[15] = encodeFactor(frequencyFactor)
+	block[16] = unscale(inverter.ApparentPower, powerFactor)
+	block[17] = encodeFactor(powerFactor)
+	block[18] = unscale(inverter.ReactivePower, powerFactor)
+	block[19] = encodeFactor(powerFactor)
+	block[20] = unscale(inverter.PowerFactor, powerFactorFactor)
+	block[21] = encodeFactor(powerFactorFactor)
+	block[22], block[23] = unscaleUint32(inverter.LifetimeEnergy, energyFactor)
+	block[24] = encodeFactor(energyFactor)
+	block[25] = unscale(inverter.DcCurrent, currentFactor)
+	block[26] = encodeFactor(currentFactor)
+	block[27] = unscale(inverter.DcVoltage, voltageFactor)
+	block[28] = encodeFactor(voltageFactor)
+	block[29] = unscale(inverter.DcPower, powerFactor)
+	block[30] = encodeFactor(powerFactor)
+	block[31] = notImplementedInt16
+	block[32] = unscale(inverter.Temperature, temperatureFactor)
+	block[33] = notImplementedInt16
+	block[34] = notImplementedInt16
+	block[35] = encodeFactor(temperatureFactor)
+	block[36] = uint16(inverter.Status)
+	block[37] = uint16(inverter.VendorStatus)
+
+	return block
+}
+
+func encodeMeter(meter Meter) []uint16 {
+	block := make([]uint16, meterLength)
+
+	block[0] = unscale(meter.AcCurrent, currentFactor)
+	block[1] = unscale(meter.AcCurrentA, currentFactor)
+	block[2] = unscale(meter.AcCurrentB, currentFactor)
+	block[3] = unscale(meter.AcCurrentC, currentFactor)
+	block[4] = encodeFactor(currentFactor)
+	block[5] = unscale(meter.VoltageLN, voltageFactor)
+	block[6] = unscale(meter.VoltageAN, voltageFactor)
+	block[7] = unscale(meter.VoltageBN, voltageFactor)
+	block[8] = unscale(meter.VoltageCN, voltageFactor)
+	block[9] = unscale(meter.VoltageLL, voltageFactor)
+	block[10] = unscale(meter.VoltageAB, voltageFactor)
+	block[11] = unscale(meter.VoltageBC, voltageFactor)
+	block[12] = unscale(meter.VoltageCA, voltageFactor)
+	block[13] = encodeFactor(voltageFactor)
+	block[14] = unscale(meter.Frequency, frequencyFactor)
+	block[15] = encodeFactor(frequencyFactor)
+	block[16] = unscale(meter.Power, powerFactor)
+	block[17] = unscale(meter.PowerA, powerFactor)
+	block[18] = unscale(meter.PowerB, powerFactor)
+	block[19] = unscale(meter.PowerC, powerFactor)
+	block[20] = encodeFactor(powerFactor)
+	block[21] = unscale(meter.ApparentPower, powerFactor)
+	block[25] = encodeFactor(powerFactor)
+	block[26] = unscale(meter.ReactivePower, powerFactor)
+	block[30] = encodeFactor(powerFactor)
+	block[31] = unscale(meter.PowerFactor, powerFactorFactor)
+	block[35] = encodeFactor(powerFactorFactor)
+	block[36], block[37] = unscaleUint32(meter.ExportedEnergy, energyFactor)
+	block[44], block[45] = unscaleUint32(meter.ImportedEnergy, energyFactor)
+	block[52] = encodeFactor(energyFactor)
+
+	return block
+}
+
+// encodeFactor encodes a scale factor as the two's complement register.
+func encodeFactor(factor int16) uint16 {
+	return uint16(factor)
+}
+
+func encodeString(registers []uint16, value string) {
+	data := make([]byte, len(registers)*2)
+	copy(data, value)
+
+	for i := range registers {
+		registers[i] = uint16(data[i*2])<<8 | uint16(data[i*2+1])
+	}
+}
+
+// unscale is the inverse of scaleInt16 and scaleUint16, the raw value is rounded to the nearest integer.
+func unscale(value float64, factor int16) uint16 {
+	return uint16(int16(math.Round(value / math.Pow10(int(factor)))))
+}
+
+func unscaleUint32(value float64, factor int16) (uint16, uint16) {
+	raw := uint32(math.Round(value / math.Pow10(int(factor))))
+
+	return uint16(raw >> 16), uint16(raw)
+}
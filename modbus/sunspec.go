@@ -0,0 +1,333 @@
+package modbus
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+const (
+	// SunSpecBase is the address of the "SunS" marker the SunSpec models of SolarEdge devices start after.
+	SunSpecBase uint16 = 40000
+
+	sunSpecMarkerHigh uint16 = 0x5375
+	sunSpecMarkerLow  uint16 = 0x6e53
+	endModelId        uint16 = 0xffff
+
+	commonModelId uint16 = 1
+
+	// maxModels stops the walk through the models of a device that does not report the end model.
+	maxModels int = 32
+
+	notImplementedUint16 uint16 = 0xffff
+	notImplementedInt16  uint16 = 0x8000
+)
+
+// InverterStatus is the operating state of an inverter (I_Status).
+type InverterStatus int
+
+const (
+	InverterStatusOff InverterStatus = iota + 1
+	InverterStatusSleeping
+	InverterStatusStarting
+	InverterStatusProducing
+	InverterStatusThrottled
+	InverterStatusShuttingDown
+	InverterStatusFault
+	InverterStatusStandby
+)
+
+func (status InverterStatus) String() string {
+	switch status {
+	case InverterStatusOff:
+		return "off"
+	case InverterStatusSleeping:
+		return "sleeping"
+	case InverterStatusStarting:
+		return "starting"
+	case InverterStatusProducing:
+		return "producing"
+	case InverterStatusThrottled:
+		return "throttled"
+	case InverterStatusShuttingDown:
+		return "shutting down"
+	case InverterStatusFault:
+		return "fault"
+	case InverterStatusStandby:
+		return "standby"
+	default:
+		return fmt.Sprintf("status %d", int(status))
+	}
+}
+
+// Common is the SunSpec common model (1) describing the device.
+type Common struct {
+	Manufacturer  string
+	Model         string
+	Options       string
+	Version       string
+	SerialNumber  string
+	DeviceAddress int
+}
+
+// Inverter is the SunSpec inverter model: 101 (single phase), 102 (split phase) or 103 (three phase).
+// Values the inverter does not implement are 0. Currents are in A, voltages in V, powers in W, energy in Wh and temperature in °C.
+type Inverter struct {
+	Common
+	ModelId int
+
+	AcCurrent      float64
+	AcCurrentA     float64
+	AcCurrentB     float64
+	AcCurrentC     float64
+	AcVoltageAB    float64
+	AcVoltageBC    float64
+	AcVoltageCA    float64
+	AcVoltageAN    float64
+	AcVoltageBN    float64
+	AcVoltageCN    float64
+	AcPower        float64
+	AcFrequency    float64
+	ApparentPower  float64
+	ReactivePower  float64
+	PowerFactor    float64 // in %
+	LifetimeEnergy float64
+	DcCurrent      float64
+	DcVoltage      float64
+	DcPower        float64
+	Temperature    float64 // of the heat sink
+	Status         InverterStatus
+	VendorStatus   int
+}
+
+// Meter is the SunSpec meter model: 201 (single phase), 202 (split phase), 203 (three phase wye) or 204 (three phase delta).
+// Following SunSpec, positive powers are imported (from the grid into the site) and negative powers are exported.
+// Currents are in A, voltages in V, powers in W and energies in Wh.
+type Meter struct {
+	Common
+	ModelId int
+
+	AcCurrent      float64
+	AcCurrentA     float64
+	AcCurrentB     float64
+	AcCurrentC     float64
+	VoltageLN      float64
+	VoltageAN      float64
+	VoltageBN      float64
+	VoltageCN      float64
+	VoltageLL      float64
+	VoltageAB      float64
+	VoltageBC      float64
+	VoltageCA      float64
+	Frequency      float64
+	Power          float64
+	PowerA         float64
+	PowerB         float64
+	PowerC         float64
+	ApparentPower  float64
+	ReactivePower  float64
+	PowerFactor    float64 // in %
+	ExportedEnergy float64
+	ImportedEnergy float64
+}
+
+// Device is everything read from an inverter: the inverter itself and the meters connected to it.
+type Device struct {
+	Inverter Inverter
+	Meters   []Meter
+}
+
+// Lengths of the models, without their id and length registers.
+const (
+	commonLength   uint16 = 65
+	inverterLength uint16 = 50
+	meterLength    uint16 = 105
+)
+
+// ReadDevice walks the SunSpec models of the device, starting at SunSpecBase, and decodes the inverter and meter models.
+// Every inverter or meter model takes the description of the common model preceding it.
+func (client *Client) ReadDevice() (Device, error) {
+	device := Device{}
+	marker, err := client.ReadHoldingRegisters(SunSpecBase, 2)
+
+	if err != nil {
+		return device, err
+	}
+
+	if marker[0] != sunSpecMarkerHigh || marker[1] != sunSpecMarkerLow {
+		return device, fmt.Errorf("%w: no marker at %d", ErrNotSunSpec, SunSpecBase)
+	}
+
+	address := SunSpecBase + 2
+	common := Common{}
+	inverterFound := false
+
+	for range maxModels {
+		header, err := client.ReadHoldingRegisters(address, 2)
+
+		if err != nil {
+			return device, err
+		}
+
+		id, length := header[0], header[1]
+
+		if id == endModelId || length == 0 {
+			break
+		}
+
+		block, err := client.ReadHoldingRegisters(address+2, length)
+
+		if err != nil {
+			return device, err
+		}
+
+		switch {
+		case id == commonModelId && length >= commonLength:
+			common = decodeCommon(block)
+		case id >= 101 && id <= 103 && length >= inverterLength:
+			device.Inverter = decodeInverter(int(id), common, block)
+			inverterFound = true
+		case id >= 201 && id <= 204 && length >= meterLength:
+			device.Meters = append(device.Meters, decodeMeter(int(id), common, block))
+		}
+
+		address += 2 + length
+	}
+
+	if !inverterFound {
+		return device, fmt.Errorf("%w: no inverter model", ErrNotSunSpec)
+	}
+
+	return device, nil
+}
+
+// ReadInverter reads the inverter, see ReadDevice.
+func (client *Client) ReadInverter() (Inverter, error) {
+	device, err := client.ReadDevice()
+
+	return device.Inverter, err
+}
+
+// ReadMeters reads the meters connected to the inverter, see ReadDevice.
+func (client *Client) ReadMeters() ([]Meter, error) {
+	device, err := client.ReadDevice()
+
+	return device.Meters, err
+}
+
+func decodeCommon(block []uint16) Common {
+	return Common{
+		Manufacturer:  decodeString(block[0:16]),
+		Model:         decodeString(block[16:32]),
+		Options:       decodeString(block[32:40]),
+		Version:       decodeString(block[40:48]),
+		SerialNumber:  decodeString(block[48:64]),
+		DeviceAddress: int(block[64]),
+	}
+}
+
+func decodeInverter(id int, common Common, block []uint16) Inverter {
+	return Inverter{
+		Common:         common,
+		ModelId:        id,
+		AcCurrent:      scaleUint16(block[0], block[4]),
+		AcCurrentA:     scaleUint16(block[1], block[4]),
+		AcCurrentB:     scaleUint16(block[2], block[4]),
+		AcCurrentC:     scaleUint16(block[3], block[4]),
+		AcVoltageAB:    scaleUint16(block[5], block[11]),
+		AcVoltageBC:    scaleUint16(block[6], block[11]),
+		AcVoltageCA:    scaleUint16(block[7], block[11]),
+		AcVoltageAN:    scaleUint16(block[8], block[11]),
+		AcVoltageBN:    scaleUint16(block[9], block[11]),
+		AcVoltageCN:    scaleUint16(block[10], block[11]),
+		AcPower:        scaleInt16(block[12], block[13]),
+		AcFrequency:    scaleUint16(block[14], block[15]),
+		ApparentPower:  scaleInt16(block[16], block[17]),
+		ReactivePower:  scaleInt16(block[18], block[19]),
+		PowerFactor:    scaleInt16(block[20], block[21]),
+		LifetimeEnergy: scaleUint32(block[22], block[23], block[24]),
+		DcCurrent:      scaleUint16(block[25], block[26]),
+		DcVoltage:      scaleUint16(block[27], block[28]),
+		DcPower:        scaleInt16(block[29], block[30]),
+		Temperature:    scaleInt16(block[32], block[35]),
+		Status:         InverterStatus(block[36]),
+		VendorStatus:   int(block[37]),
+	}
+}
+
+func decodeMeter(id int, common Common, block []uint16) Meter {
+	return Meter{
+		Common:         common,
+		ModelId:        id,
+		AcCurrent:      scaleInt16(block[0], block[4]),
+		AcCurrentA:     scaleInt16(block[1], block[4]),
+		AcCurrentB:     scaleInt16(block[2], block[4]),
+		AcCurrentC:     scaleInt16(block[3], block[4]),
+		VoltageLN:      scaleInt16(block[5], block[13]),
+		VoltageAN:      scaleInt16(block[6], block[13]),
+		VoltageBN:      scaleInt16(block[7], block[13]),
+		VoltageCN:      scaleInt16(block[8], block[13]),
+		VoltageLL:      scaleInt16(block[9], block[13]),
+		VoltageAB:      scaleInt16(block[10], block[13]),
+		VoltageBC:      scaleInt16(block[11], block[13]),
+		VoltageCA:      scaleInt16(block[12], block[13]),
+		Frequency:      scaleInt16(block[14], block[15]),
+		Power:          scaleInt16(block[16], block[20]),
+		PowerA:         scaleInt16(block[17], block[20]),
+		PowerB:         scaleInt16(block[18], block[20]),
+		PowerC:         scaleInt16(block[19], block[20]),
+		ApparentPower:  scaleInt16(block[21], block[25]),
+		ReactivePower:  scaleInt16(block[26], block[30]),
+		PowerFactor:    scaleInt16(block[31], block[35]),
+		ExportedEnergy: scaleUint32(block[36], block[37], block[52]),
+		ImportedEnergy: scaleUint32(block[44], block[45], block[52]),
+	}
+}
+
+// decodeString decodes a string of two characters per register, padded with zeros or spaces.
+func decodeString(registers []uint16) string {
+	data := make([]byte, 0, len(registers)*2)
+
+	for _, register := range registers {
+		data = append(data, byte(register>>8), byte(register))
+	}
+
+	return strings.TrimRight(string(data), "\x00 ")
+}
+
+// scale applies a SunSpec scale factor: the value is raw * 10^factor. A factor that is not implemented scales to 0.
+func scale(raw float64, factor uint16) float64 {
+	if factor == notImplementedInt16 {
+		return 0
+	}
+
+	exponent := int(int16(factor))
+
+	// dividing by a power of ten rounds to the nearest decimal, e.g. 1234 with -2 is 12.34 rather than 12.340000000000002
+	if exponent < 0 {
+		return raw / math.Pow10(-exponent)
+	}
+
+	return raw * math.Pow10(exponent)
+}
+
+func scaleUint16(raw uint16, factor uint16) float64 {
+	if raw == notImplementedUint16 {
+		return 0
+	}
+
+	return scale(float64(raw), factor)
+}
+
+func scaleInt16(raw uint16, factor uint16) float64 {
+	if raw == notImplementedInt16 {
+		return 0
+	}
+
+	return scale(float64(int16(raw)), factor)
+}
+
+// scaleUint32 scales an accumulator of two registers, most significant first. An accumulator that is not implemented is 0.
+func scaleUint32(high uint16, low uint16, factor uint16) float64 {
+	return scale(float64(uint32(high)<<16|uint32(low)), factor)
+}
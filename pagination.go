@@ -0,0 +1,110 @@
+package golaredge
+
+import (
+	"context"
+	"iter"
+)
+
+// defaultPageSize is the largest page size the list endpoints accept.
+const defaultPageSize int = 100
+
+// paginate walks every page of a list endpoint, starting at startIndex, until count items were returned.
+// fetch returns the items of the page starting at startIndex and the total count of items.
+func paginate[T any](startIndex *int, fetch func(startIndex int) ([]T, int, error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		index := 0
+
+		if startIndex != nil {
+			index = *startIndex
+		}
+
+		for {
+			page, count, err := fetch(index)
+
+			if err != nil {
+				var empty T
+
+				yield(empty, err)
+
+				return
+			}
+
+			for _, item := range page {
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			index += len(page)
+
+			if len(page) == 0 || index >= count {
+				return
+			}
+		}
+	}
+}
+
+// collect returns every item of an iterator, or the items up to the first error together with that error.
+func collect[T any](items iter.Seq2[T, error]) ([]T, error) {
+	result := []T{}
+
+	for item, err := range items {
+		if err != nil {
+			return result, err
+		}
+
+		result = append(result, item)
+	}
+
+	return result, nil
+}
+
+// Sites returns an iterator over every site matching params. The pages (of params.Size sites, 100 by default) are only fetched
+// when the iteration reaches them, so breaking out of the loop saves requests. An error (e.g. the error of ctx) ends the iteration.
+func (client *Client) Sites(ctx context.Context, params SiteListParams) iter.Seq2[Site, error] {
+	size := defaultPageSize
+
+	if params.Size != nil && *params.Size > 0 {
+		size = *params.Size
+	}
+
+	return paginate(params.StartIndex, func(startIndex int) ([]Site, int, error) {
+		pageParams := params
+		pageParams.Size = &size
+		pageParams.StartIndex = &startIndex
+
+		response, err := client.GetSiteList(ctx, pageParams)
+
+		return response.Sites.Site, response.Sites.Count, err
+	})
+}
+
+// AllSites returns every site matching params, see Sites.
+func (client *Client) AllSites(ctx context.Context, params SiteListParams) ([]Site, error) {
+	return collect(client.Sites(ctx, params))
+}
+
+// Accounts returns an iterator over every account matching params. The pages (of params.Size accounts, 100 by default) are only fetched
+// when the iteration reaches them, so breaking out of the loop saves requests. An error (e.g. the error of ctx) ends the iteration.
+func (client *Client) Accounts(ctx context.Context, params AccountListParams) iter.Seq2[Account, error] {
+	size := defaultPageSize
+
+	if params.Size != nil && *params.Size > 0 {
+		size = *params.Size
+	}
+
+	return paginate(params.StartIndex, func(startIndex int) ([]Account, int, error) {
+		pageParams := params
+		pageParams.Size = &size
+		pageParams.StartIndex = &startIndex
+
+		response, err := client.GetAccountList(ctx, pageParams)
+
+		return response.Accounts.List, response.Accounts.Count, err
+	})
+}
+
+// AllAccounts returns every account matching params, see Accounts.
+func (client *Client) AllAccounts(ctx context.Context, params AccountListParams) ([]Account, error) {
+	return collect(client.Accounts(ctx, params))
+}
@@ -0,0 +1,51 @@
+package golaredge
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// TestClientSites checks that every page is fetched until the count of the response is reached.
+func TestClientSites(t *testing.T) {
+	const total = 250
+	requests := 0
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		size, _ := strconv.Atoi(r.URL.Query().Get("size"))
+		startIndex, _ := strconv.Atoi(r.URL.Query().Get("startIndex"))
+		sites := []string{}
+
+		for id := startIndex; id < min(startIndex+size, total); id++ {
+			sites = append(sites, fmt.Sprintf(`{"id":%d}`, id))
+		}
+
+		fmt.Fprintf(w, `{"sites":{"count":%d,"site":[%s]}}`, total, strings.Join(sites, ","))
+	})
+
+	sites, err := client.AllSites(t.Context(), SiteListParams{})
+
+	if err != nil {
+		t.Fatalf("AllSites() error = %v", err)
+	}
+
+	if len(sites) != total || sites[total-1].Id != total-1 || requests != 3 {
+		t.Errorf("AllSites() = %d sites in %d requests, want %d sites in 3 requests", len(sites), requests, total)
+	}
+
+	requests = 0
+
+	for site := range client.Sites(t.Context(), SiteListParams{}) {
+		if site.Id == 5 {
+			break
+		}
+	}
+
+	if requests != 1 {
+		t.Errorf("breaking out of Sites() made %d requests, want 1", requests)
+	}
+}
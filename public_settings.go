@@ -0,0 +1,6 @@
+package golaredge
+
+type PublicSettings struct {
+	Name     string `json:"name,omitempty"`
+	IsPublic bool   `json:"isPublic"`
+}
@@ -0,0 +1,228 @@
+package golaredge
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+)
+
+// window is a part of a date range that complies with the limit of an endpoint.
+type window struct {
+	start time.Time
+	end   time.Time
+}
+
+// splitRange splits [start, end] into consecutive windows, each at most as long as limit (a single window if limit is zero).
+// Consecutive windows share their boundary, the duplicated values are removed when merging the results.
+func splitRange(start time.Time, end time.Time, limit RangeLimit) []window {
+	if start.IsZero() || end.IsZero() || end.Before(start) || limit.IsZero() {
+		// let the request builder report the invalid range
+		return []window{{start, end}}
+	}
+
+	windows := []window{}
+
+	for current := start; ; {
+		windowEnd := limit.end(current)
+
+		if !windowEnd.Before(end) {
+			return append(windows, window{current, end})
+		}
+
+		windows = append(windows, window{current, windowEnd})
+		current = windowEnd
+	}
+}
+
+// mergeByDate sorts values by date and removes the values with a date that was already seen.
+func mergeByDate[T any](values []T, date func(T) time.Time) []T {
+	slices.SortStableFunc(values, func(a T, b T) int {
+		return date(a).Compare(date(b))
+	})
+
+	return slices.CompactFunc(values, func(a T, b T) bool {
+		return date(a).Equal(date(b))
+	})
+}
+
+func dateValueDate(value DateValue) time.Time {
+	return value.Date.Time
+}
+
+// GetSitePowerRange returns the power measurements of any date range by splitting it in windows of one month.
+// When a request fails or ctx is done, the values fetched so far are returned together with the error.
+func (client *Client) GetSitePowerRange(ctx context.Context, params SitePowerParams) (SitePower, error) {
+	result := SitePower{}
+
+	for _, window := range splitRange(params.StartTime, params.EndTime, rangeLimit(EndpointSitePower, 0)) {
+		windowParams := params
+		windowParams.StartTime = window.start
+		windowParams.EndTime = window.end
+
+		response, err := client.GetSitePower(ctx, windowParams)
+
+		if err != nil {
+			result.Values = mergeByDate(result.Values, dateValueDate)
+
+			return result, err
+		}
+
+		values := append(result.Values, response.Power.Values...)
+		result = response.Power
+		result.Values = values
+	}
+
+	result.Values = mergeByDate(result.Values, dateValueDate)
+
+	return result, nil
+}
+
+// GetSiteEnergyRange returns the energy measurements of any date range.
+// The range is split in windows of one month for QUARTER_OF_AN_HOUR and HOUR and of one year for DAY.
+// When a request fails or ctx is done, the values fetched so far are returned together with the error.
+func (client *Client) GetSiteEnergyRange(ctx context.Context, params SiteEnergyParams) (SiteEnergy, error) {
+	result := SiteEnergy{}
+
+	for _, window := range splitRange(params.StartDate, params.EndDate, rangeLimit(EndpointSiteEnergy, params.TimeUnit)) {
+		windowParams := params
+		windowParams.StartDate = window.start
+		windowParams.EndDate = window.end
+
+		response, err := client.GetSiteEnergy(ctx, windowParams)
+
+		if err != nil {
+			result.Values = mergeByDate(result.Values, dateValueDate)
+
+			return result, err
+		}
+
+		values := append(result.Values, response.Energy.Values...)
+		result = response.Energy
+		result.Values = values
+	}
+
+	result.Values = mergeByDate(result.Values, dateValueDate)
+
+	return result, nil
+}
+
+// GetStorageInformationRange returns the battery telemetries of any date range by splitting it in windows of one week.
+// The telemetries of every battery are merged by serial number.
+// When a request fails or ctx is done, the telemetries fetched so far are returned together with the error.
+func (client *Client) GetStorageInformationRange(ctx context.Context, params StorageInformationParams) (StorageInformation, error) {
+	batteries := []Battery{}
+	var err error
+
+	for _, window := range splitRange(params.StartTime, params.EndTime, rangeLimit(EndpointSiteStorageData, 0)) {
+		windowParams := params
+		windowParams.StartTime = window.start
+		windowParams.EndTime = window.end
+
+		var response StorageInformationResponse
+		response, err = client.GetStorageInformation(ctx, windowParams)
+
+		if err != nil {
+			break
+		}
+
+		for _, battery := range response.StorageData.Batteries {
+			index := slices.IndexFunc(batteries, func(merged Battery) bool {
+				return merged.SerialNumber == battery.SerialNumber
+			})
+
+			if index == -1 {
+				batteries = append(batteries, battery)
+
+				continue
+			}
+
+			batteries[index].Telemetries = append(batteries[index].Telemetries, battery.Telemetries...)
+		}
+	}
+
+	for i := range batteries {
+		batteries[i].Telemetries = mergeByDate(batteries[i].Telemetries, func(telemetry BatteryTelemetry) time.Time {
+			return telemetry.TimeStamp.Time
+		})
+		batteries[i].TelemetryCount = len(batteries[i].Telemetries)
+	}
+
+	return StorageInformation{BatteryCount: len(batteries), Batteries: batteries}, err
+}
+
+// GetInverterTechnicalDataRange returns the inverter telemetries of any date range by splitting it in windows of one week.
+// When a request fails or ctx is done, the telemetries fetched so far are returned together with the error.
+func (client *Client) GetInverterTechnicalDataRange(ctx context.Context, params InverterTechnicalDataParams) (InverterTechnicalData, error) {
+	telemetries := []InverterTelemetry{}
+	var err error
+
+	for _, window := range splitRange(params.StartTime, params.EndTime, rangeLimit(EndpointInverterTechnicalData, 0)) {
+		windowParams := params
+		windowParams.StartTime = window.start
+		windowParams.EndTime = window.end
+
+		var response InverterTechnicalDataResponse
+		response, err = client.GetInverterTechnicalData(ctx, windowParams)
+
+		if err != nil {
+			break
+		}
+
+		telemetries = append(telemetries, response.Data.Telemetries...)
+	}
+
+	telemetries = mergeByDate(telemetries, func(telemetry InverterTelemetry) time.Time {
+		return telemetry.Date.Time
+	})
+
+	return InverterTechnicalData{Count: len(telemetries), Telemetries: telemetries}, err
+}
+
+// GetSensorDataRange returns the sensor telemetries of any date range by splitting it in windows of one week.
+// The telemetries are merged per gateway the sensors are connected to.
+// When a request fails or ctx is done, the telemetries fetched so far are returned together with the error.
+func (client *Client) GetSensorDataRange(ctx context.Context, params SensorDataParams) (SensorData, error) {
+	gateways := []SensorGatewayData{}
+	var err error
+
+	for _, window := range splitRange(params.StartDate, params.EndDate, rangeLimit(EndpointSiteSensors, 0)) {
+		windowParams := params
+		windowParams.StartDate = window.start
+		windowParams.EndDate = window.end
+
+		var response SensorDataResponse
+		response, err = client.GetSensorData(ctx, windowParams)
+
+		if err != nil {
+			break
+		}
+
+		for _, gateway := range response.SiteSensors.Data {
+			index := slices.IndexFunc(gateways, func(merged SensorGatewayData) bool {
+				return merged.ConnectedTo == gateway.ConnectedTo
+			})
+
+			if index == -1 {
+				gateways = append(gateways, gateway)
+
+				continue
+			}
+
+			gateways[index].Telemetries = append(gateways[index].Telemetries, gateway.Telemetries...)
+		}
+	}
+
+	for i := range gateways {
+		gateways[i].Telemetries = mergeByDate(gateways[i].Telemetries, func(telemetry SensorTelemetry) time.Time {
+			return telemetry.Date.Time
+		})
+		gateways[i].Count = len(gateways[i].Telemetries)
+	}
+
+	slices.SortFunc(gateways, func(a SensorGatewayData, b SensorGatewayData) int {
+		return cmp.Compare(a.ConnectedTo, b.ConnectedTo)
+	})
+
+	return SensorData{Data: gateways}, err
+}
@@ -0,0 +1,127 @@
+package golaredge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+)
+
+// TestSplitRange checks that the windows cover the range and comply with the limit.
+func TestSplitRange(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 20, 12, 0, 0, 0, time.UTC)
+
+	windows := splitRange(start, end, oneWeek)
+
+	if len(windows) != 3 {
+		t.Fatalf("splitRange() = %d windows, want 3", len(windows))
+	}
+
+	if !windows[0].start.Equal(start) || !windows[2].end.Equal(end) || !windows[1].start.Equal(windows[0].end) {
+		t.Errorf("splitRange() = %v", windows)
+	}
+
+	for _, window := range windows {
+		if window.end.After(oneWeek.end(window.start)) {
+			t.Errorf("window %v exceeds one week", window)
+		}
+	}
+}
+
+// TestSplitRangeEndOfMonth checks that monthly windows starting at the end of a month end at the end of the next month.
+func TestSplitRangeEndOfMonth(t *testing.T) {
+	start := time.Date(2023, 1, 31, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2023, 4, 15, 0, 0, 0, 0, time.UTC)
+
+	want := []window{
+		{start, time.Date(2023, 2, 28, 0, 0, 0, 0, time.UTC)},
+		{time.Date(2023, 2, 28, 0, 0, 0, 0, time.UTC), time.Date(2023, 3, 28, 0, 0, 0, 0, time.UTC)},
+		{time.Date(2023, 3, 28, 0, 0, 0, 0, time.UTC), end},
+	}
+
+	if windows := splitRange(start, end, oneMonth); !slices.Equal(windows, want) {
+		t.Errorf("splitRange() = %v, want %v", windows, want)
+	}
+
+	if limitEnd := oneYear.end(time.Date(2024, 2, 29, 12, 0, 0, 0, time.UTC)); !limitEnd.Equal(time.Date(2025, 2, 28, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("one year after 2024-02-29 = %s, want 2025-02-28", limitEnd)
+	}
+}
+
+// TestClientGetSitePowerRange checks that a quarter is fetched in monthly windows and merged into one ordered series.
+func TestClientGetSitePowerRange(t *testing.T) {
+	requests := 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		// the first value of every window duplicates the last value of the previous one
+		start := r.URL.Query().Get("startTime")[:10]
+		end := r.URL.Query().Get("endTime")[:10]
+
+		fmt.Fprintf(w, `{"power":{"timeUnit":"QUARTER_OF_AN_HOUR","unit":"W","values":[{"date":"%s 00:00:00","value":1},{"date":"%s 00:00:00","value":2}]}}`, start, end)
+	})
+
+	power, err := client.GetSitePowerRange(t.Context(), SitePowerParams{
+		SiteId:    1,
+		StartTime: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC),
+	})
+
+	if err != nil {
+		t.Fatalf("GetSitePowerRange() error = %v", err)
+	}
+
+	if requests != 3 {
+		t.Errorf("requests = %d, want 3", requests)
+	}
+
+	want := []string{"2024-01-01", "2024-02-01", "2024-03-01", "2024-03-15"}
+
+	if len(power.Values) != len(want) {
+		t.Fatalf("GetSitePowerRange() = %d values, want %d", len(power.Values), len(want))
+	}
+
+	for i := range want {
+		if date := power.Values[i].Date.Format(dateLayout); date != want[i] {
+			t.Errorf("value %d date = %s, want %s", i, date, want[i])
+		}
+	}
+
+	if power.Unit != "W" {
+		t.Errorf("Unit = %q, want W", power.Unit)
+	}
+}
+
+// TestClientGetSitePowerRangeCancelled checks that cancelling the context stops the remaining windows and keeps the values fetched so far.
+func TestClientGetSitePowerRangeCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	requests := 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		// cancelled while the second window is in flight
+		if requests == 2 {
+			cancel()
+		}
+
+		fmt.Fprintf(w, `{"power":{"timeUnit":"QUARTER_OF_AN_HOUR","unit":"W","values":[{"date":"%s","value":1}]}}`, r.URL.Query().Get("startTime"))
+	})
+
+	power, err := client.GetSitePowerRange(ctx, SitePowerParams{
+		SiteId:    1,
+		StartTime: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC),
+	})
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("GetSitePowerRange() error = %v, want context.Canceled", err)
+	}
+
+	if requests != 2 || len(power.Values) != 1 {
+		t.Errorf("requests = %d, values = %d, want the values of the first window only", requests, len(power.Values))
+	}
+}
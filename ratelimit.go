@@ -0,0 +1,265 @@
+package golaredge
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimitPolicy decides what happens to a request that would exceed one of the limits of the API.
+type RateLimitPolicy int
+
+const (
+	// RateLimitBlock waits until the request is allowed, i.e. until another request finishes or the daily budget resets.
+	RateLimitBlock RateLimitPolicy = iota
+
+	// RateLimitFailFast returns an error matching ErrQuotaExceeded or ErrTooManyConcurrent immediately.
+	RateLimitFailFast
+
+	// RateLimitQueue waits like RateLimitBlock, but at most RateLimitConfig.QueueTimeout before failing like RateLimitFailFast.
+	RateLimitQueue
+)
+
+const (
+	DefaultDailyLimit    int = 300
+	DefaultMaxConcurrent int = 3
+)
+
+// RateLimitConfig configures a RateLimiter. Zero values are replaced by the limits documented by SolarEdge.
+type RateLimitConfig struct {
+	Policy RateLimitPolicy
+
+	// DailyLimit is the number of requests allowed per api key and per day (default 300).
+	DailyLimit int
+
+	// SiteDailyLimit is the number of requests allowed per site and per day (default 300).
+	SiteDailyLimit int
+
+	// MaxConcurrent is the number of requests that can be executed at the same time (default 3).
+	MaxConcurrent int
+
+	// QueueTimeout is the maximum time a request waits when using RateLimitQueue.
+	QueueTimeout time.Duration
+}
+
+// RateLimiter keeps track of the daily budget of every api key and site and of the number of requests in flight.
+// The daily budget resets at midnight UTC. A single RateLimiter can be shared by clients using the same api key.
+type RateLimiter struct {
+	config RateLimitConfig
+	now    func() time.Time
+
+	mutex     sync.Mutex
+	changed   chan struct{}
+	day       string
+	active    int
+	keyUsage  map[string]int
+	siteUsage map[int]int
+}
+
+// NewRateLimiter returns a rate limiter enforcing the limits of config.
+func NewRateLimiter(config RateLimitConfig) *RateLimiter {
+	if config.DailyLimit <= 0 {
+		config.DailyLimit = DefaultDailyLimit
+	}
+
+	if config.SiteDailyLimit <= 0 {
+		config.SiteDailyLimit = DefaultDailyLimit
+	}
+
+	if config.MaxConcurrent <= 0 {
+		config.MaxConcurrent = DefaultMaxConcurrent
+	}
+
+	return &RateLimiter{
+		config:    config,
+		now:       time.Now,
+		changed:   make(chan struct{}),
+		keyUsage:  map[string]int{},
+		siteUsage: map[int]int{},
+	}
+}
+
+// Remaining returns the number of requests apiKey can still execute today.
+func (limiter *RateLimiter) Remaining(apiKey string) int {
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+
+	limiter.resetIfNewDay()
+
+	return max(limiter.config.DailyLimit-limiter.keyUsage[apiKey], 0)
+}
+
+// RemainingForSite returns the number of requests that can still be executed today for siteId.
+func (limiter *RateLimiter) RemainingForSite(siteId int) int {
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+
+	limiter.resetIfNewDay()
+
+	return max(limiter.config.SiteDailyLimit-limiter.siteUsage[siteId], 0)
+}
+
+// acquire reserves a request for apiKey and siteIds, waiting according to the policy.
+// An empty apiKey (the version endpoints) only counts towards the concurrency limit.
+// Waiting ends with the error of ctx once it is done. The returned function must be called once the request has finished.
+func (limiter *RateLimiter) acquire(ctx context.Context, apiKey string, siteIds []int) (func(), error) {
+	var deadline <-chan time.Time
+
+	if limiter.config.Policy == RateLimitQueue {
+		timer := time.NewTimer(limiter.config.QueueTimeout)
+		defer timer.Stop()
+
+		deadline = timer.C
+	}
+
+	for {
+		limiter.mutex.Lock()
+		limiter.resetIfNewDay()
+
+		err := limiter.check(apiKey, siteIds)
+
+		if err == nil {
+			limiter.take(apiKey, siteIds)
+			limiter.mutex.Unlock()
+
+			return limiter.release, nil
+		}
+
+		if limiter.config.Policy == RateLimitFailFast {
+			limiter.mutex.Unlock()
+
+			return nil, err
+		}
+
+		changed := limiter.changed
+		untilReset := limiter.nextReset().Sub(limiter.now())
+
+		limiter.mutex.Unlock()
+
+		resetTimer := time.NewTimer(untilReset)
+
+		select {
+		case <-changed:
+		case <-resetTimer.C:
+		case <-deadline:
+			resetTimer.Stop()
+
+			return nil, err
+		case <-ctx.Done():
+			resetTimer.Stop()
+
+			return nil, ctx.Err()
+		}
+
+		resetTimer.Stop()
+	}
+}
+
+// exhaust marks the budget of apiKey as used, e.g. because the API reported the quota was exceeded by requests made elsewhere.
+func (limiter *RateLimiter) exhaust(apiKey string) {
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+
+	limiter.resetIfNewDay()
+	limiter.keyUsage[apiKey] = limiter.config.DailyLimit
+}
+
+func (limiter *RateLimiter) check(apiKey string, siteIds []int) error {
+	if limiter.active >= limiter.config.MaxConcurrent {
+		return fmt.Errorf("%w: %d requests are already in flight", ErrTooManyConcurrent, limiter.active)
+	}
+
+	if apiKey == "" {
+		return nil
+	}
+
+	if limiter.keyUsage[apiKey] >= limiter.config.DailyLimit {
+		return fmt.Errorf("%w: daily budget of %d requests for the api key is used", ErrQuotaExceeded, limiter.config.DailyLimit)
+	}
+
+	for i := range siteIds {
+		if limiter.siteUsage[siteIds[i]] >= limiter.config.SiteDailyLimit {
+			return fmt.Errorf("%w: daily budget of %d requests for site %d is used", ErrQuotaExceeded, limiter.config.SiteDailyLimit, siteIds[i])
+		}
+	}
+
+	return nil
+}
+
+func (limiter *RateLimiter) take(apiKey string, siteIds []int) {
+	limiter.active++
+
+	if apiKey == "" {
+		return
+	}
+
+	limiter.keyUsage[apiKey]++
+
+	for i := range siteIds {
+		limiter.siteUsage[siteIds[i]]++
+	}
+}
+
+func (limiter *RateLimiter) release() {
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+
+	limiter.active--
+	limiter.notify()
+}
+
+// notify wakes up every request waiting in acquire. The mutex must be held.
+func (limiter *RateLimiter) notify() {
+	close(limiter.changed)
+	limiter.changed = make(chan struct{})
+}
+
+// resetIfNewDay clears the daily usage once the day (UTC) changes. The mutex must be held.
+func (limiter *RateLimiter) resetIfNewDay() {
+	day := limiter.now().UTC().Format(dateLayout)
+
+	if day == limiter.day {
+		return
+	}
+
+	limiter.day = day
+	limiter.keyUsage = map[string]int{}
+	limiter.siteUsage = map[int]int{}
+	limiter.notify()
+}
+
+func (limiter *RateLimiter) nextReset() time.Time {
+	year, month, day := limiter.now().UTC().Date()
+
+	return time.Date(year, month, day+1, 0, 0, 0, 0, time.UTC)
+}
+
+// siteIdsFromPath returns the site ids of a path like "site/1/energy", "sites/1,2/overview" or "equipment/1/list".
+func siteIdsFromPath(path string) []int {
+	segments := strings.Split(strings.TrimPrefix(path, "/"), "/")
+
+	if len(segments) < 2 {
+		return nil
+	}
+
+	switch segments[0] {
+	case "site", "sites", "equipment":
+	default:
+		return nil
+	}
+
+	siteIds := []int{}
+
+	for _, id := range strings.Split(segments[1], ",") {
+		siteId, err := strconv.Atoi(id)
+
+		if err == nil {
+			siteIds = append(siteIds, siteId)
+		}
+	}
+
+	return siteIds
+}
@@ -0,0 +1,98 @@
+package golaredge
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestRateLimiterDailyBudget checks that the budget is tracked per api key and per site and resets the next day.
+func TestRateLimiterDailyBudget(t *testing.T) {
+	now := time.Date(2024, 5, 1, 23, 0, 0, 0, time.UTC)
+	limiter := NewRateLimiter(RateLimitConfig{Policy: RateLimitFailFast, DailyLimit: 2, SiteDailyLimit: 1})
+	limiter.now = func() time.Time { return now }
+
+	release, err := limiter.acquire(t.Context(), "key", []int{1})
+
+	if err != nil {
+		t.Fatalf("acquire() error = %v", err)
+	}
+
+	release()
+
+	if _, err := limiter.acquire(t.Context(), "key", []int{1}); !errors.Is(err, ErrQuotaExceeded) {
+		t.Errorf("acquire() for used site error = %v, want ErrQuotaExceeded", err)
+	}
+
+	release, err = limiter.acquire(t.Context(), "key", []int{2})
+
+	if err != nil {
+		t.Fatalf("acquire() error = %v", err)
+	}
+
+	release()
+
+	if remaining := limiter.Remaining("key"); remaining != 0 {
+		t.Errorf("Remaining() = %d, want 0", remaining)
+	}
+
+	if _, err := limiter.acquire(t.Context(), "key", []int{3}); !errors.Is(err, ErrQuotaExceeded) {
+		t.Errorf("acquire() for used key error = %v, want ErrQuotaExceeded", err)
+	}
+
+	now = now.Add(2 * time.Hour)
+
+	if remaining := limiter.Remaining("key"); remaining != 2 {
+		t.Errorf("Remaining() the next day = %d, want 2", remaining)
+	}
+}
+
+// TestRateLimiterConcurrency checks that waiting requests are released once a request in flight finishes.
+func TestRateLimiterConcurrency(t *testing.T) {
+	limiter := NewRateLimiter(RateLimitConfig{Policy: RateLimitQueue, MaxConcurrent: 1, QueueTimeout: 10 * time.Millisecond})
+
+	release, err := limiter.acquire(t.Context(), "key", nil)
+
+	if err != nil {
+		t.Fatalf("acquire() error = %v", err)
+	}
+
+	if _, err := limiter.acquire(t.Context(), "key", nil); !errors.Is(err, ErrTooManyConcurrent) {
+		t.Errorf("acquire() error = %v, want ErrTooManyConcurrent after the queue timeout", err)
+	}
+
+	limiter.config.QueueTimeout = time.Second
+
+	go func() {
+		time.Sleep(5 * time.Millisecond)
+		release()
+	}()
+
+	release, err = limiter.acquire(t.Context(), "key", nil)
+
+	if err != nil {
+		t.Fatalf("acquire() error = %v, want the request to be released", err)
+	}
+
+	release()
+}
+
+// TestRateLimiterContext checks that a request waiting for the limiter gives up once its context is done.
+func TestRateLimiterContext(t *testing.T) {
+	limiter := NewRateLimiter(RateLimitConfig{MaxConcurrent: 1})
+	release, err := limiter.acquire(t.Context(), "key", nil)
+
+	if err != nil {
+		t.Fatalf("acquire() error = %v", err)
+	}
+
+	defer release()
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := limiter.acquire(ctx, "key", nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("acquire() error = %v, want context.DeadlineExceeded", err)
+	}
+}
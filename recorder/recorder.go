@@ -0,0 +1,279 @@
+// Package recorder provides an http.RoundTripper recording the exchanges of a client with the Monitoring API to a fixture file
+// and replaying them, so that real responses can be used in tests without access to SolarEdge.
+package recorder
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	golaredge "github.com/Adrigorithm/GolarEdge"
+)
+
+// Mode decides which requests a Recorder sends to the API and which ones it answers from its fixture.
+type Mode int
+
+const (
+	// ModeRecord sends every request and records the responses, replacing the fixture.
+	ModeRecord Mode = iota
+
+	// ModeReplay answers the recorded requests from the fixture and sends the other ones without recording them.
+	ModeReplay
+
+	// ModeReplayOrRecord answers the recorded requests from the fixture and sends and records the other ones.
+	ModeReplayOrRecord
+
+	// ModeStrictReplay answers the recorded requests from the fixture and fails the other ones with ErrNotRecorded.
+	ModeStrictReplay
+)
+
+// ErrNotRecorded is returned in ModeStrictReplay for a request that is not part of the fixture.
+var ErrNotRecorded = errors.New("request is not recorded")
+
+// Interaction is a recorded exchange. The request is in its canonical form (see golaredge.Request.Canonical),
+// so it never contains the api key and matches the same request sent to any base url.
+type Interaction struct {
+	Request     string `json:"request"`
+	StatusCode  int    `json:"statusCode"`
+	ContentType string `json:"contentType,omitempty"`
+
+	// RetryAfter is the Retry-After header of the response, e.g. of a 429 (see golaredge.APIError.RetryAfter).
+	RetryAfter string `json:"retryAfter,omitempty"`
+
+	// Body is the response body if it is text, Binary otherwise (e.g. a site image).
+	Body   string `json:"body,omitempty"`
+	Binary []byte `json:"binary,omitempty"`
+}
+
+// Recorder is an http.RoundTripper recording to and replaying from a fixture file, see Mode.
+// Identical requests are replayed in the order they were recorded, the last response is repeated once they are exhausted.
+type Recorder struct {
+	path      string
+	mode      Mode
+	transport http.RoundTripper
+
+	mutex        sync.Mutex
+	interactions []Interaction
+	replayed     map[string]int
+	changed      bool
+}
+
+// New returns a recorder using the fixture at path, sending requests with transport (http.DefaultTransport if nil).
+// The fixture must exist in ModeReplay and ModeStrictReplay. Call Close to write the recorded interactions.
+func New(path string, mode Mode, transport http.RoundTripper) (*Recorder, error) {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	recorder := &Recorder{path: path, mode: mode, transport: transport, interactions: []Interaction{}, replayed: map[string]int{}}
+
+	if mode == ModeRecord {
+		return recorder, nil
+	}
+
+	fixture, err := os.ReadFile(path)
+
+	if errors.Is(err, os.ErrNotExist) && mode == ModeReplayOrRecord {
+		return recorder, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(fixture, &recorder.interactions); err != nil {
+		return nil, fmt.Errorf("fixture %s: %w", path, err)
+	}
+
+	return recorder, nil
+}
+
+// Client returns an http.Client using the recorder, to pass to golaredge.WithHTTPClient.
+func (recorder *Recorder) Client() *http.Client {
+	return &http.Client{Transport: recorder}
+}
+
+// Interactions returns the interactions of the fixture, including the ones recorded so far.
+func (recorder *Recorder) Interactions() []Interaction {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	return append([]Interaction{}, recorder.interactions...)
+}
+
+// RoundTrip answers a request from the fixture or sends it, depending on the mode.
+func (recorder *Recorder) RoundTrip(httpRequest *http.Request) (*http.Response, error) {
+	key, err := requestKey(httpRequest.URL)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if recorder.mode != ModeRecord {
+		if interaction, ok := recorder.replay(key); ok {
+			return interaction.response(httpRequest), nil
+		}
+
+		if recorder.mode == ModeStrictReplay {
+			return nil, fmt.Errorf("%w: %s", ErrNotRecorded, key)
+		}
+	}
+
+	response, err := recorder.transport.RoundTrip(httpRequest)
+
+	if err != nil || recorder.mode == ModeReplay {
+		return response, err
+	}
+
+	body, err := io.ReadAll(response.Body)
+	response.Body.Close()
+
+	if err != nil {
+		return nil, err
+	}
+
+	response.Body = io.NopCloser(bytes.NewReader(body))
+	recorder.record(newInteraction(key, response, body))
+
+	return response, nil
+}
+
+// Close writes the fixture. In ModeRecord it always replaces the fixture, even if nothing was recorded,
+// in the other modes it writes the fixture only if interactions were recorded.
+func (recorder *Recorder) Close() error {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	if !recorder.changed && recorder.mode != ModeRecord {
+		return nil
+	}
+
+	fixture, err := json.MarshalIndent(recorder.interactions, "", "  ")
+
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(recorder.path), 0o755); err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(recorder.path, append(fixture, '\n'), 0o644); err != nil {
+		return err
+	}
+
+	recorder.changed = false
+
+	return nil
+}
+
+// requestKey returns the canonical form of the request of requestUrl. The path prefix of the base url of the client (e.g. of a proxy,
+// see golaredge.WithBaseURL) is stripped, so that the fixture does not depend on the base url.
+func requestKey(requestUrl *url.URL) (string, error) {
+	stripped := *requestUrl
+	stripped.RawPath = ""
+
+	for {
+		request, err := golaredge.ParseRequest(stripped.String())
+
+		if err != nil {
+			return "", err
+		}
+
+		_, known := request.Endpoint.Spec()
+		_, rest, found := strings.Cut(strings.TrimPrefix(stripped.Path, "/"), "/")
+
+		if known || !found {
+			return request.Canonical(), nil
+		}
+
+		stripped.Path = "/" + rest
+	}
+}
+
+// replay returns the next recorded interaction of key.
+func (recorder *Recorder) replay(key string) (Interaction, bool) {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	matches := []Interaction{}
+
+	for i := range recorder.interactions {
+		if recorder.interactions[i].Request == key {
+			matches = append(matches, recorder.interactions[i])
+		}
+	}
+
+	if len(matches) == 0 {
+		return Interaction{}, false
+	}
+
+	index := min(recorder.replayed[key], len(matches)-1)
+	recorder.replayed[key]++
+
+	return matches[index], true
+}
+
+func (recorder *Recorder) record(interaction Interaction) {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	recorder.interactions = append(recorder.interactions, interaction)
+	recorder.changed = true
+}
+
+func newInteraction(key string, response *http.Response, body []byte) Interaction {
+	interaction := Interaction{
+		Request:     key,
+		StatusCode:  response.StatusCode,
+		ContentType: response.Header.Get("Content-Type"),
+		RetryAfter:  response.Header.Get("Retry-After"),
+	}
+
+	if utf8.Valid(body) {
+		interaction.Body = string(body)
+	} else {
+		interaction.Binary = body
+	}
+
+	return interaction
+}
+
+func (interaction Interaction) response(request *http.Request) *http.Response {
+	body := interaction.Binary
+
+	if body == nil {
+		body = []byte(interaction.Body)
+	}
+
+	header := http.Header{}
+
+	if interaction.ContentType != "" {
+		header.Set("Content-Type", interaction.ContentType)
+	}
+
+	if interaction.RetryAfter != "" {
+		header.Set("Retry-After", interaction.RetryAfter)
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", interaction.StatusCode, http.StatusText(interaction.StatusCode)),
+		StatusCode:    interaction.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       request,
+	}
+}
@@ -0,0 +1,212 @@
+package recorder
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	golaredge "github.com/Adrigorithm/GolarEdge"
+	"github.com/Adrigorithm/GolarEdge/fakeapi"
+)
+
+func newTestClient(recorder *Recorder, baseUrl string) *golaredge.Client {
+	return golaredge.NewClient("test-key", golaredge.WithHTTPClient(recorder.Client()), golaredge.WithBaseURL(baseUrl), golaredge.WithRateLimiter(nil))
+}
+
+// TestRecordAndReplay checks that recorded responses are replayed without the server and without the api key in the fixture.
+func TestRecordAndReplay(t *testing.T) {
+	server, err := fakeapi.NewServer(fakeapi.DefaultConfig())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "fixtures", "site.json")
+	recorder, err := New(path, ModeRecord, nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	recorded, err := newTestClient(recorder, server.URL()).GetSite(t.Context(), golaredge.SiteParams{SiteId: 1})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := newTestClient(recorder, server.URL()).GetSiteImage(t.Context(), golaredge.SiteImageParams{SiteId: 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := recorder.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	server.Close()
+
+	if fixture, _ := os.ReadFile(path); strings.Contains(string(fixture), "test-key") {
+		t.Errorf("the fixture contains the api key: %s", fixture)
+	}
+
+	replayer, err := New(path, ModeStrictReplay, nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := newTestClient(replayer, "http://replay.invalid")
+	replayed, err := client.GetSite(t.Context(), golaredge.SiteParams{SiteId: 1})
+
+	if err != nil || replayed.Details.Name != recorded.Details.Name || !replayed.Details.InstallationDate.Equal(recorded.Details.InstallationDate.Time) {
+		t.Errorf("got %+v, %v, want %+v", replayed.Details, err, recorded.Details)
+	}
+
+	if image, err := client.GetSiteImage(t.Context(), golaredge.SiteImageParams{SiteId: 1}); err != nil || !strings.HasPrefix(string(image), "\x89PNG") {
+		t.Errorf("got %d bytes, %v", len(image), err)
+	}
+
+	if _, err := client.GetSite(t.Context(), golaredge.SiteParams{SiteId: 2}); !errors.Is(err, ErrNotRecorded) {
+		t.Errorf("error = %v, want ErrNotRecorded", err)
+	}
+}
+
+// TestReplayOrRecord checks that only the requests missing from the fixture are sent.
+func TestReplayOrRecord(t *testing.T) {
+	server, err := fakeapi.NewServer(fakeapi.DefaultConfig())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(server.Close)
+
+	path := filepath.Join(t.TempDir(), "versions.json")
+
+	for range 2 {
+		recorder, err := New(path, ModeReplayOrRecord, nil)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := newTestClient(recorder, server.URL()).GetCurrentVersion(t.Context()); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := recorder.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if requests := len(server.Requests()); requests != 1 {
+		t.Errorf("the server received %d requests, want 1", requests)
+	}
+}
+
+// TestRecordRetryAfter checks that the Retry-After header of a response is replayed.
+func TestRecordRetryAfter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "120")
+		http.Error(w, "Too many requests", http.StatusTooManyRequests)
+	}))
+	t.Cleanup(server.Close)
+
+	path := filepath.Join(t.TempDir(), "quota.json")
+	recorder, err := New(path, ModeRecord, nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := newTestClient(recorder, server.URL).GetSite(t.Context(), golaredge.SiteParams{SiteId: 1}); err == nil {
+		t.Fatal("error = nil, want a 429")
+	}
+
+	if err := recorder.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	replayer, err := New(path, ModeStrictReplay, nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = newTestClient(replayer, "http://replay.invalid").GetSite(t.Context(), golaredge.SiteParams{SiteId: 1})
+	var apiError *golaredge.APIError
+
+	if !errors.As(err, &apiError) || apiError.StatusCode != http.StatusTooManyRequests || apiError.RetryAfter != 2*time.Minute {
+		t.Errorf("error = %#v, want a 429 to retry after 2m", err)
+	}
+}
+
+// TestRecordReplacesFixture checks that ModeRecord replaces the fixture even if nothing is recorded.
+func TestRecordReplacesFixture(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "empty.json")
+
+	if err := os.WriteFile(path, []byte(`[{"request":"version/current","statusCode":200}]`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	recorder, err := New(path, ModeRecord, nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := recorder.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if fixture, err := os.ReadFile(path); err != nil || strings.TrimSpace(string(fixture)) != "[]" {
+		t.Errorf("fixture = %s, %v, want no interactions", fixture, err)
+	}
+}
+
+// TestRecordBaseURLPrefix checks that the path prefix of the base url is not part of the recorded requests.
+func TestRecordBaseURLPrefix(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/proxy/solaredge/site/1/details" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+
+		w.Write([]byte(`{"details":{"id":1,"name":"Home"}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	path := filepath.Join(t.TempDir(), "proxy.json")
+	recorder, err := New(path, ModeRecord, nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := newTestClient(recorder, server.URL+"/proxy/solaredge/").GetSite(t.Context(), golaredge.SiteParams{SiteId: 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := recorder.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if interactions := recorder.Interactions(); len(interactions) != 1 || interactions[0].Request != "site/1/details" {
+		t.Errorf("got %+v, want the request without the prefix", interactions)
+	}
+
+	for _, baseUrl := range []string{"http://replay.invalid", "http://replay.invalid/other"} {
+		replayer, err := New(path, ModeStrictReplay, nil)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if site, err := newTestClient(replayer, baseUrl).GetSite(t.Context(), golaredge.SiteParams{SiteId: 1}); err != nil || site.Details.Name != "Home" {
+			t.Errorf("%s: got %+v, %v", baseUrl, site.Details, err)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package golaredge
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// redacted replaces the api key in the urls, errors and logs of the library.
+const redacted = "REDACTED"
+
+// RedactURL returns rawUrl with the value of every api_key query parameter replaced by REDACTED, so that it can be logged.
+// rawUrl does not need to be a valid url.
+func RedactURL(rawUrl string) string {
+	var builder strings.Builder
+
+	for {
+		start := strings.Index(rawUrl, "api_key=")
+
+		if start == -1 {
+			builder.WriteString(rawUrl)
+
+			return builder.String()
+		}
+
+		start += len("api_key=")
+		end := strings.IndexAny(rawUrl[start:], "&#")
+
+		if end == -1 {
+			end = len(rawUrl) - start
+		}
+
+		builder.WriteString(rawUrl[:start])
+		builder.WriteString(redacted)
+		rawUrl = rawUrl[start+end:]
+	}
+}
+
+// redactError redacts the url of a *url.Error in err, as returned by net/http for a failed request.
+func redactError(err error) error {
+	var urlError *url.Error
+
+	if errors.As(err, &urlError) {
+		urlError.URL = RedactURL(urlError.URL)
+	}
+
+	return err
+}
+
+// WithLogger logs every request sent to the API and its response (or error) to logger, with the api key redacted.
+// Requests are logged at debug level, failed requests at warn level.
+func WithLogger(logger *slog.Logger) Option {
+	return func(client *Client) {
+		client.logger = logger
+	}
+}
+
+// logRequest logs the start of a request and returns the function logging its outcome.
+func (client *Client) logRequest(ctx context.Context, request Request) func(statusCode int, size int, err error) {
+	if client.logger == nil {
+		return func(int, int, error) {}
+	}
+
+	start := time.Now()
+	attributes := []slog.Attr{slog.String("endpoint", string(request.Endpoint)), slog.String("request", request.Canonical())}
+	client.logger.LogAttrs(ctx, slog.LevelDebug, "golaredge request", attributes...)
+
+	return func(statusCode int, size int, err error) {
+		attributes = append(attributes, slog.Duration("duration", time.Since(start)))
+
+		if statusCode != 0 {
+			attributes = append(attributes, slog.Int("status", statusCode))
+		}
+
+		if err != nil {
+			client.logger.LogAttrs(ctx, slog.LevelWarn, "golaredge request failed", append(attributes, slog.String("error", err.Error()))...)
+
+			return
+		}
+
+		client.logger.LogAttrs(ctx, slog.LevelDebug, "golaredge response", append(attributes, slog.Int("size", size))...)
+	}
+}
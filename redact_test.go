@@ -0,0 +1,61 @@
+package golaredge
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestRedactURL checks that every api key of a url is redacted and the rest is left as is.
+func TestRedactURL(t *testing.T) {
+	tests := map[string]string{
+		"https://monitoringapi.solaredge.com/site/1/overview?api_key=secret": "https://monitoringapi.solaredge.com/site/1/overview?api_key=REDACTED",
+		"/site/1/energy?api_key=secret&timeUnit=DAY":                         "/site/1/energy?api_key=REDACTED&timeUnit=DAY",
+		"/sites/list?size=1&api_key=secret#top&api_key=other":                "/sites/list?size=1&api_key=REDACTED#top&api_key=REDACTED",
+		"https://monitoringapi.solaredge.com/version/current":                "https://monitoringapi.solaredge.com/version/current",
+		"%zz?api_key=secret": "%zz?api_key=REDACTED",
+	}
+
+	for rawUrl, want := range tests {
+		if got := RedactURL(rawUrl); got != want {
+			t.Errorf("RedactURL(%q) = %q, want %q", rawUrl, got, want)
+		}
+	}
+
+	request, _, _ := buildRequest(siteRequest, SiteParams{SiteId: 1}, "secret", ValidationStrict, time.Now())
+
+	if got := request.String(); got != "https://monitoringapi.solaredge.com/site/1/details?api_key=REDACTED" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+// TestClientRedaction checks that neither the errors nor the logs of the client contain the api key.
+func TestClientRedaction(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	baseUrl := server.URL
+	server.Close()
+
+	var logs bytes.Buffer
+	client := NewClient("secret", WithBaseURL(baseUrl), WithLogger(slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))))
+	_, err := client.GetSiteOverview(t.Context(), SiteOverviewParams{SiteId: 1})
+
+	var urlError *url.Error
+
+	if !errors.As(err, &urlError) || !strings.Contains(err.Error(), "api_key=REDACTED") || strings.Contains(err.Error(), "secret") {
+		t.Errorf("error = %v, want the api key redacted", err)
+	}
+
+	if !strings.Contains(logs.String(), "golaredge request failed") || strings.Contains(logs.String(), "secret") {
+		t.Errorf("logs = %s", logs.String())
+	}
+
+	if _, err := ParseRequest("%zz?api_key=secret"); err == nil || strings.Contains(err.Error(), "secret") {
+		t.Errorf("ParseRequest() error = %v, want the api key redacted", err)
+	}
+}
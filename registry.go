@@ -0,0 +1,327 @@
+package golaredge
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// RangeLimit is the maximum difference between the start and end of a request, in calendar years, months and days.
+// The zero value means the range is not limited.
+type RangeLimit struct {
+	Years  int
+	Months int
+	Days   int
+}
+
+var (
+	oneWeek  = RangeLimit{Days: 7}
+	oneMonth = RangeLimit{Months: 1}
+	oneYear  = RangeLimit{Years: 1}
+)
+
+func (limit RangeLimit) IsZero() bool {
+	return limit == RangeLimit{}
+}
+
+// String returns the limit as e.g. "1 month" or "1 year, 6 months", "unlimited" for the zero value.
+func (limit RangeLimit) String() string {
+	if limit.IsZero() {
+		return "unlimited"
+	}
+
+	parts := []string{}
+
+	for _, part := range []struct {
+		count int
+		unit  string
+	}{{limit.Years, "year"}, {limit.Months, "month"}, {limit.Days, "day"}} {
+		switch {
+		case part.count == 1:
+			parts = append(parts, "1 "+part.unit)
+		case part.count != 0:
+			parts = append(parts, fmt.Sprintf("%d %ss", part.count, part.unit))
+		}
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// end returns the latest end allowed for start. Unlike time.Time.AddDate, the years and months do not overflow into the next month:
+// one month after January 31 is the last day of February, not March 2.
+func (limit RangeLimit) end(start time.Time) time.Time {
+	year, month, day := start.Date()
+	first := time.Date(year+limit.Years, month+time.Month(limit.Months), 1, start.Hour(), start.Minute(), start.Second(), start.Nanosecond(), start.Location())
+	lastDay := first.AddDate(0, 1, -1).Day()
+
+	return first.AddDate(0, 0, min(day, lastDay)-1+limit.Days)
+}
+
+// EndpointSpec describes an endpoint of the API: its parameters and the limits the API enforces on them.
+type EndpointSpec struct {
+	Endpoint Endpoint
+	Auth     AuthMode
+
+	// Params is the zero value of the params struct of the endpoint, nil if the endpoint has no parameters.
+	Params any
+
+	// Required and Optional are the fields of Params the endpoint requires or accepts.
+	Required []string
+	Optional []string
+
+	// StartParam and EndParam are the fields of Params holding the range of a time series endpoint.
+	StartParam string
+	EndParam   string
+
+	// TimeUnits are the time units the endpoint accepts, nil if it has no time unit. DAY is used if none is specified.
+	TimeUnits []TimeUnit
+
+	// MaxRange is the maximum range per time unit, a time unit that is not listed is not limited.
+	// The range of an endpoint without time unit is listed under 0.
+	MaxRange map[TimeUnit]RangeLimit
+
+	// Bulk is the bulk endpoint of the same data for several sites, if any.
+	Bulk Endpoint
+
+	// MaxSiteIds is the maximum number of site ids of a bulk endpoint, 0 for other endpoints.
+	MaxSiteIds int
+}
+
+var allTimeUnits = []TimeUnit{TimeUnitQuarterOfAnHour, TimeUnitHour, TimeUnitDay, TimeUnitWeek, TimeUnitMonth, TimeUnitYear}
+
+// the daily resolution is limited to a year, the smaller ones to a month
+var timeUnitRanges = map[TimeUnit]RangeLimit{TimeUnitQuarterOfAnHour: oneMonth, TimeUnitHour: oneMonth, TimeUnitDay: oneYear}
+
+var endpointSpecs = map[Endpoint]EndpointSpec{
+	// Site Data API
+	EndpointSiteList: {
+		Params:   SiteListParams{},
+		Optional: []string{"Size", "StartIndex", "SearchText", "SortProperty", "SortOrder", "Status"},
+	},
+	EndpointSiteDetails: {
+		Params:   SiteParams{},
+		Required: []string{"SiteId"},
+	},
+	EndpointSiteDataPeriod: {
+		Params:   SiteDataStartAndEndDatesParams{},
+		Required: []string{"SiteId"},
+		Bulk:     EndpointSiteDataPeriodBulk,
+	},
+	EndpointSiteDataPeriodBulk: {
+		Params:     SiteDataStartAndEndDatesBulkParams{},
+		Required:   []string{"SiteIds"},
+		MaxSiteIds: MaxBulkSiteIds,
+	},
+	EndpointSiteEnergy: {
+		Params:     SiteEnergyParams{},
+		Required:   []string{"SiteId", "StartDate", "EndDate"},
+		Optional:   []string{"TimeUnit"},
+		StartParam: "StartDate",
+		EndParam:   "EndDate",
+		TimeUnits:  allTimeUnits,
+		MaxRange:   timeUnitRanges,
+		Bulk:       EndpointSiteEnergyBulk,
+	},
+	EndpointSiteEnergyBulk: {
+		Params:     SiteEnergyBulkParams{},
+		Required:   []string{"SiteIds", "StartDate", "EndDate"},
+		Optional:   []string{"TimeUnit"},
+		StartParam: "StartDate",
+		EndParam:   "EndDate",
+		TimeUnits:  allTimeUnits,
+		MaxRange:   timeUnitRanges,
+		MaxSiteIds: MaxBulkSiteIds,
+	},
+	EndpointSiteTimeFrameEnergy: {
+		Params:     SiteEnergyTimePeriodParams{},
+		Required:   []string{"SiteId", "StartDate", "EndDate"},
+		StartParam: "StartDate",
+		EndParam:   "EndDate",
+		MaxRange:   map[TimeUnit]RangeLimit{0: oneYear},
+		Bulk:       EndpointSiteTimeFrameEnergyBulk,
+	},
+	EndpointSiteTimeFrameEnergyBulk: {
+		Params:     SiteEnergyTimePeriodBulkParams{},
+		Required:   []string{"SiteIds", "StartDate", "EndDate"},
+		StartParam: "StartDate",
+		EndParam:   "EndDate",
+		MaxRange:   map[TimeUnit]RangeLimit{0: oneYear},
+		MaxSiteIds: MaxBulkSiteIds,
+	},
+	EndpointSitePower: {
+		Params:     SitePowerParams{},
+		Required:   []string{"SiteId", "StartTime", "EndTime"},
+		StartParam: "StartTime",
+		EndParam:   "EndTime",
+		MaxRange:   map[TimeUnit]RangeLimit{0: oneMonth},
+		Bulk:       EndpointSitePowerBulk,
+	},
+	EndpointSitePowerBulk: {
+		Params:     SitePowerBulkParams{},
+		Required:   []string{"SiteIds", "StartTime", "EndTime"},
+		StartParam: "StartTime",
+		EndParam:   "EndTime",
+		MaxRange:   map[TimeUnit]RangeLimit{0: oneMonth},
+		MaxSiteIds: MaxBulkSiteIds,
+	},
+	EndpointSiteOverview: {
+		Params:   SiteOverviewParams{},
+		Required: []string{"SiteId"},
+		Bulk:     EndpointSiteOverviewBulk,
+	},
+	EndpointSiteOverviewBulk: {
+		Params:     SiteOverviewBulkParams{},
+		Required:   []string{"SiteIds"},
+		MaxSiteIds: MaxBulkSiteIds,
+	},
+	EndpointSitePowerDetails: {
+		Params:     SitePowerDetailedParams{},
+		Required:   []string{"SiteId", "StartTime", "EndTime"},
+		Optional:   []string{"Meters"},
+		StartParam: "StartTime",
+		EndParam:   "EndTime",
+		MaxRange:   map[TimeUnit]RangeLimit{0: oneMonth},
+	},
+	EndpointSiteEnergyDetails: {
+		Params:     SiteEnergyDetailedParams{},
+		Required:   []string{"SiteId", "StartTime", "EndTime"},
+		Optional:   []string{"TimeUnit", "Meters"},
+		StartParam: "StartTime",
+		EndParam:   "EndTime",
+		TimeUnits:  allTimeUnits,
+		MaxRange:   timeUnitRanges,
+	},
+	EndpointSiteCurrentPowerFlow: {
+		Params:   SitePowerFlowParams{},
+		Required: []string{"SiteId"},
+	},
+	EndpointSiteStorageData: {
+		Params:     StorageInformationParams{},
+		Required:   []string{"SiteId", "StartTime", "EndTime"},
+		Optional:   []string{"Serials"},
+		StartParam: "StartTime",
+		EndParam:   "EndTime",
+		MaxRange:   map[TimeUnit]RangeLimit{0: oneWeek},
+	},
+	EndpointSiteImage: {
+		Params:   SiteImageParams{},
+		Required: []string{"SiteId"},
+		Optional: []string{"MaxWidth", "MaxHeight", "Hash"},
+	},
+	EndpointSiteImageName: {
+		Params:   SiteImageParams{},
+		Required: []string{"SiteId", "Name"},
+		Optional: []string{"MaxWidth", "MaxHeight", "Hash"},
+	},
+	EndpointSiteEnvironmentalBenefits: {
+		Params:   SiteEnvironmentalBenefitsParams{},
+		Required: []string{"SiteId"},
+		Optional: []string{"SystemUnits"},
+	},
+	EndpointSiteInstallerImage: {
+		Params:   SiteImageParams{},
+		Required: []string{"SiteId"},
+	},
+	EndpointSiteInstallerImageName: {
+		Params:   SiteImageParams{},
+		Required: []string{"SiteId", "Name"},
+	},
+
+	// Site Equipment API
+	EndpointComponentsList: {
+		Params:   ComponentsListParams{},
+		Required: []string{"SiteId"},
+	},
+	EndpointSiteInventory: {
+		Params:   InventoryParams{},
+		Required: []string{"SiteId"},
+	},
+	EndpointInverterTechnicalData: {
+		Params:     InverterTechnicalDataParams{},
+		Required:   []string{"SiteId", "SerialNumber", "StartTime", "EndTime"},
+		StartParam: "StartTime",
+		EndParam:   "EndTime",
+		MaxRange:   map[TimeUnit]RangeLimit{0: oneWeek},
+	},
+	EndpointEquipmentChangeLog: {
+		Params:   EquipmentChangeLogParams{},
+		Required: []string{"SiteId", "SerialNumber"},
+	},
+
+	// Account List API
+	EndpointAccountList: {
+		Params:   AccountListParams{},
+		Optional: []string{"Size", "StartIndex", "SearchText", "SortProperty", "SortOrder"},
+	},
+
+	// Meters API
+	EndpointSiteMeters: {
+		Params:     MetersDataParams{},
+		Required:   []string{"SiteId", "StartTime", "EndTime"},
+		Optional:   []string{"TimeUnit", "Meters"},
+		StartParam: "StartTime",
+		EndParam:   "EndTime",
+		TimeUnits:  allTimeUnits,
+		MaxRange:   timeUnitRanges,
+	},
+
+	// Sensors API
+	EndpointSensorsList: {
+		Params:   SensorsListParams{},
+		Required: []string{"SiteId"},
+	},
+	EndpointSiteSensors: {
+		Params:     SensorDataParams{},
+		Required:   []string{"SiteId", "StartDate", "EndDate"},
+		StartParam: "StartDate",
+		EndParam:   "EndDate",
+		MaxRange:   map[TimeUnit]RangeLimit{0: oneWeek},
+	},
+
+	// API Versions
+	EndpointCurrentVersion:   {Auth: AuthNone},
+	EndpointSupportedVersion: {Auth: AuthNone},
+}
+
+// Spec returns the description of the endpoint, false for an endpoint that is not part of the API.
+func (endpoint Endpoint) Spec() (EndpointSpec, bool) {
+	spec, ok := endpointSpecs[endpoint]
+	spec.Endpoint = endpoint
+
+	return spec, ok
+}
+
+// Endpoints returns the description of every endpoint of the API, sorted by endpoint.
+func Endpoints() []EndpointSpec {
+	specs := make([]EndpointSpec, 0, len(endpointSpecs))
+
+	for endpoint := range endpointSpecs {
+		spec, _ := endpoint.Spec()
+		specs = append(specs, spec)
+	}
+
+	slices.SortFunc(specs, func(a EndpointSpec, b EndpointSpec) int {
+		return strings.Compare(string(a.Endpoint), string(b.Endpoint))
+	})
+
+	return specs
+}
+
+// TimeUnit returns the time unit sent for timeUnit: timeUnit itself if the endpoint accepts it, DAY otherwise.
+func (spec EndpointSpec) TimeUnit(timeUnit TimeUnit) TimeUnit {
+	if slices.Contains(spec.TimeUnits, timeUnit) {
+		return timeUnit
+	}
+
+	return TimeUnitDay
+}
+
+// Limit returns the maximum range of a request with timeUnit.
+func (spec EndpointSpec) Limit(timeUnit TimeUnit) RangeLimit {
+	if spec.TimeUnits == nil {
+		return spec.MaxRange[0]
+	}
+
+	return spec.MaxRange[spec.TimeUnit(timeUnit)]
+}
@@ -0,0 +1,84 @@
+package golaredge
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestEndpointSpecs checks that the fields listed by every spec exist in its params struct.
+func TestEndpointSpecs(t *testing.T) {
+	for _, spec := range Endpoints() {
+		if spec.Params == nil {
+			continue
+		}
+
+		params := reflect.TypeOf(spec.Params)
+		fields := append(append([]string{}, spec.Required...), spec.Optional...)
+
+		if spec.StartParam != "" {
+			fields = append(fields, spec.StartParam, spec.EndParam)
+		}
+
+		for _, field := range fields {
+			if _, ok := params.FieldByName(field); !ok {
+				t.Errorf("%s: %s has no field %s", spec.Endpoint, params.Name(), field)
+			}
+		}
+
+		if bulk, ok := spec.Bulk.Spec(); spec.Bulk != "" && (!ok || bulk.MaxSiteIds == 0) {
+			t.Errorf("%s: bulk endpoint %s is not a bulk endpoint", spec.Endpoint, spec.Bulk)
+		}
+	}
+}
+
+// TestRangeLimits checks that the range limits of the registry are enforced, including the exact limit.
+func TestRangeLimits(t *testing.T) {
+	start := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		endpoint Endpoint
+		timeUnit TimeUnit
+		end      time.Time
+		tooLarge bool
+	}{
+		{EndpointSitePower, 0, time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), false},
+		{EndpointSitePower, 0, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), true},
+		{EndpointSiteEnergy, TimeUnitHour, start.AddDate(0, 2, 0), true},
+		{EndpointSiteEnergy, 0, start.AddDate(0, 2, 0), false},
+		{EndpointSiteEnergy, TimeUnitDay, start.AddDate(1, 0, 1), true},
+		{EndpointSiteEnergy, TimeUnitMonth, start.AddDate(5, 0, 0), false},
+		{EndpointSiteSensors, 0, start.AddDate(0, 0, 8), true},
+	}
+
+	for _, test := range tests {
+		validation := &validation{}
+		checkRange(test.endpoint, test.timeUnit, start, test.end, validation)
+
+		if validation.failed() != test.tooLarge {
+			t.Errorf("%s %v until %s: errors = %v, want too large %v", test.endpoint, test.timeUnit, test.end, validation.errors, test.tooLarge)
+		}
+	}
+
+	if limit := rangeLimit(EndpointSiteEnergyDetails, TimeUnitQuarterOfAnHour); limit.String() != "1 month" {
+		t.Errorf("got %s, want 1 month", limit)
+	}
+}
+
+// TestBulkMaxSiteIds checks that a bulk request with more site ids than the endpoint accepts fails.
+func TestBulkMaxSiteIds(t *testing.T) {
+	siteIds := make([]int, MaxBulkSiteIds+1)
+
+	for i := range siteIds {
+		siteIds[i] = i
+	}
+
+	if _, err := GetSiteOverviewBulkRequest(SiteOverviewBulkParams{SiteIds: siteIds}); !errors.Is(err, ErrTooManySiteIDs) {
+		t.Errorf("error = %v, want ErrTooManySiteIDs", err)
+	}
+
+	if _, err := GetSiteOverviewBulkRequest(SiteOverviewBulkParams{SiteIds: siteIds[1:]}); err != nil {
+		t.Errorf("error = %v", err)
+	}
+}
@@ -0,0 +1,198 @@
+package golaredge
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"strings"
+)
+
+// Endpoint identifies an endpoint of the API by its path template, e.g. "site/{siteId}/overview".
+type Endpoint string
+
+// The endpoints of the API, named after the params and responses of the same data.
+const (
+	EndpointSiteList                  Endpoint = "sites/list"
+	EndpointSiteDetails               Endpoint = "site/{siteId}/details"
+	EndpointSiteDataPeriod            Endpoint = "site/{siteId}/dataPeriod"
+	EndpointSiteDataPeriodBulk        Endpoint = "sites/{siteIds}/dataPeriod"
+	EndpointSiteEnergy                Endpoint = "site/{siteId}/energy"
+	EndpointSiteEnergyBulk            Endpoint = "sites/{siteIds}/energy"
+	EndpointSiteTimeFrameEnergy       Endpoint = "site/{siteId}/timeFrameEnergy"
+	EndpointSiteTimeFrameEnergyBulk   Endpoint = "sites/{siteIds}/timeFrameEnergy"
+	EndpointSitePower                 Endpoint = "site/{siteId}/power"
+	EndpointSitePowerBulk             Endpoint = "sites/{siteIds}/power"
+	EndpointSiteOverview              Endpoint = "site/{siteId}/overview"
+	EndpointSiteOverviewBulk          Endpoint = "sites/{siteIds}/overview"
+	EndpointSitePowerDetails          Endpoint = "site/{siteId}/powerDetails"
+	EndpointSiteEnergyDetails         Endpoint = "site/{siteId}/energyDetails"
+	EndpointSiteCurrentPowerFlow      Endpoint = "site/{siteId}/currentPowerFlow"
+	EndpointSiteStorageData           Endpoint = "site/{siteId}/storageData"
+	EndpointSiteImage                 Endpoint = "site/{siteId}/siteImage"
+	EndpointSiteImageName             Endpoint = "site/{siteId}/siteImage/{name}"
+	EndpointSiteEnvironmentalBenefits Endpoint = "site/{siteId}/envBenefits"
+	EndpointSiteInstallerImage        Endpoint = "site/{siteId}/installerImage"
+	EndpointSiteInstallerImageName    Endpoint = "site/{siteId}/installerImage/{name}"
+	EndpointSiteInventory             Endpoint = "site/{siteId}/inventory"
+	EndpointSiteMeters                Endpoint = "site/{siteId}/meters"
+	EndpointSiteSensors               Endpoint = "site/{siteId}/sensors"
+	EndpointComponentsList            Endpoint = "equipment/{siteId}/list"
+	EndpointInverterTechnicalData     Endpoint = "equipment/{siteId}/{serialNumber}/data"
+	EndpointEquipmentChangeLog        Endpoint = "equipment/{siteId}/{serialNumber}/changeLog"
+	EndpointSensorsList               Endpoint = "equipment/{siteId}/sensors"
+	EndpointAccountList               Endpoint = "accounts/list"
+	EndpointCurrentVersion            Endpoint = "version/current"
+	EndpointSupportedVersion          Endpoint = "version/supported"
+)
+
+// AuthMode is how a request authenticates against the API.
+type AuthMode int
+
+const (
+	// AuthAPIKey sends the api key as the api_key query parameter.
+	AuthAPIKey AuthMode = iota
+
+	// AuthNone sends no credentials, only the version endpoints accept this.
+	AuthNone
+)
+
+// Request is a request to the API independent of the host and the api key, as built by the Get*Request functions.
+// Its canonical form is stable, so caches, deduplication and fixtures can key on it.
+type Request struct {
+	Endpoint Endpoint
+
+	// PathParams are the values of the placeholders of the endpoint, e.g. "siteId": "1".
+	PathParams map[string]string
+
+	// Query holds the query parameters without the api key.
+	Query url.Values
+
+	Auth AuthMode
+}
+
+// ParseRequest returns the request of a url built by one of the Get*Request functions (or of the same path and query on another host).
+func ParseRequest(requestUrl string) (Request, error) {
+	parsed, err := url.Parse(requestUrl)
+
+	if err != nil {
+		return Request{}, redactError(err)
+	}
+
+	path := strings.TrimPrefix(parsed.Path, "/")
+	endpoint := Endpoint(endpointTemplate(path))
+	request := Request{Endpoint: endpoint, PathParams: map[string]string{}, Query: parsed.Query(), Auth: endpoint.auth()}
+	templateSegments := strings.Split(string(endpoint), "/")
+	segments := strings.Split(path, "/")
+
+	for i := range templateSegments {
+		if strings.HasPrefix(templateSegments[i], "{") {
+			request.PathParams[strings.Trim(templateSegments[i], "{}")] = segments[i]
+		}
+	}
+
+	request.Query.Del("api_key")
+
+	return request, nil
+}
+
+// auth returns the auth mode of the endpoint, AuthAPIKey for an endpoint that is not part of the API.
+func (endpoint Endpoint) auth() AuthMode {
+	spec, _ := endpoint.Spec()
+
+	return spec.Auth
+}
+
+// Path returns the path of the request, the endpoint with its placeholders replaced by the path parameters, e.g. "site/1/overview".
+func (request Request) Path() string {
+	path := string(request.Endpoint)
+
+	for name, value := range request.PathParams {
+		path = strings.ReplaceAll(path, "{"+name+"}", value)
+	}
+
+	return path
+}
+
+// Canonical returns the path and the query sorted by key, e.g. "site/1/energy?endDate=2024-01-31&startDate=2024-01-01&timeUnit=DAY".
+func (request Request) Canonical() string {
+	if len(request.Query) == 0 {
+		return request.Path()
+	}
+
+	return request.Path() + "?" + request.Query.Encode()
+}
+
+// Hash returns the hex encoded SHA-256 of the canonical form.
+func (request Request) Hash() string {
+	sum := sha256.Sum256([]byte(request.Canonical()))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// AuthenticatedURL renders the request against https://monitoringapi.solaredge.com/, authenticated with apiKey if the endpoint requires it.
+// The url contains the api key, use String to log it.
+func (request Request) AuthenticatedURL(apiKey string) (string, error) {
+	baseUrl, _ := url.Parse(baseUri)
+
+	return request.url(baseUrl, apiKey)
+}
+
+// String renders the request against https://monitoringapi.solaredge.com/ with the api key redacted, e.g. "https://monitoringapi.solaredge.com/site/1/overview?api_key=REDACTED".
+func (request Request) String() string {
+	requestUrl, _ := request.AuthenticatedURL(redacted)
+
+	return requestUrl
+}
+
+func (request Request) url(baseUrl *url.URL, apiKey string) (string, error) {
+	values := url.Values{}
+
+	for key := range request.Query {
+		values[key] = request.Query[key]
+	}
+
+	if request.Auth == AuthAPIKey {
+		if apiKey == "" {
+			return "", ErrMissingAPIKey
+		}
+
+		values.Set("api_key", apiKey)
+	}
+
+	uriBuilder := url.URL{
+		Scheme:   baseUrl.Scheme,
+		Host:     baseUrl.Host,
+		Path:     strings.TrimSuffix(baseUrl.Path, "/") + "/" + request.Path(),
+		RawQuery: values.Encode(),
+	}
+
+	return uriBuilder.String(), nil
+}
+
+// endpointTemplate replaces the identifiers in a path by placeholders, e.g. "site/1/energy" becomes "site/{siteId}/energy".
+func endpointTemplate(path string) string {
+	segments := strings.Split(strings.TrimPrefix(path, "/"), "/")
+
+	if len(segments) < 2 || segments[1] == "list" {
+		return strings.Join(segments, "/")
+	}
+
+	switch segments[0] {
+	case "site":
+		segments[1] = "{siteId}"
+
+		if len(segments) > 3 {
+			segments[3] = "{name}"
+		}
+	case "sites":
+		segments[1] = "{siteIds}"
+	case "equipment":
+		segments[1] = "{siteId}"
+
+		if len(segments) > 3 {
+			segments[2] = "{serialNumber}"
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,62 @@
+package golaredge
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestRequestCanonical checks that equal params always build the same canonical form, independent of the order of the values.
+func TestRequestCanonical(t *testing.T) {
+	first, _, err := buildRequest(siteListRequest, SiteListParams{Status: []SiteStatus{SiteStatusDisabled, SiteStatusActive}, SortProperty: SiteSortByName}, "key", ValidationLenient, time.Now())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	second, _, _ := buildRequest(siteListRequest, SiteListParams{Status: []SiteStatus{SiteStatusActive, SiteStatusDisabled}, SortProperty: SiteSortByName}, "key", ValidationLenient, time.Now())
+
+	if first.Canonical() != "sites/list?sortProperty=Name&status=Active%2CDisabled" || first.Canonical() != second.Canonical() || first.Hash() != second.Hash() {
+		t.Errorf("got %q (%s) and %q (%s)", first.Canonical(), first.Hash(), second.Canonical(), second.Hash())
+	}
+
+	if first.Endpoint != EndpointSiteList || first.Auth != AuthAPIKey {
+		t.Errorf("got endpoint %q, auth %v", first.Endpoint, first.Auth)
+	}
+}
+
+// TestParseRequest checks that the url of a Get*Request function parses back into the request it was rendered from.
+func TestParseRequest(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	params := InverterTechnicalDataParams{SiteId: 1, SerialNumber: "SN1", StartTime: start, EndTime: start.AddDate(0, 0, 1)}
+	built, _, _ := buildRequest(inverterTechnicalDataRequest, params, "key", ValidationLenient, time.Now())
+	request, err := GetInverterTechnicalDataRequest(params)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	requestUrl, err := request.AuthenticatedURL("key")
+
+	if err != nil || !strings.Contains(requestUrl, "api_key=key") || strings.Contains(request.String(), "api_key=key") {
+		t.Fatalf("got %s (%s), %v, want the api key in the authenticated url only", requestUrl, request, err)
+	}
+
+	parsed, err := ParseRequest(requestUrl)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if parsed.Endpoint != EndpointInverterTechnicalData || parsed.PathParams["serialNumber"] != "SN1" || parsed.Canonical() != built.Canonical() {
+		t.Errorf("got %+v, want %q", parsed, built.Canonical())
+	}
+
+	if parsed.Query.Has("api_key") {
+		t.Errorf("the canonical form contains the api key: %s", parsed.Canonical())
+	}
+
+	if versionUrl := GetCurrentVersionRequest().String(); versionUrl != "https://monitoringapi.solaredge.com/version/current" {
+		t.Errorf("got %s", versionUrl)
+	}
+}
@@ -0,0 +1,588 @@
+package golaredge
+
+type Site struct {
+	Id        int    `json:"id"`
+	Name      string `json:"name"`
+	AccountId int    `json:"accountId"`
+
+	// { Active | Pending Communication }
+	Status string `json:"status"`
+
+	PeakPower float32 `json:"peakPower"`
+
+	// Precision: 2006-01-02 15:04:05
+	LastUpdateTime Timestamp `json:"lastUpdateTime"`
+
+	// { EUR }
+	Currency string `json:"currency"`
+
+	// Precision: 2006-01-02 15:04:05
+	InstallationDate Timestamp `json:"installationDate"`
+
+	// Precision: 2006-01-02
+	PtoDate Timestamp `json:"ptoDate"`
+
+	Notes string `json:"notes"`
+
+	// { Optimizers and inverters | Safety and monitoring interface | Monitoring combiner boxes }
+	SiteType string `json:"type"`
+
+	Location Location `json:"location"`
+
+	//  only available when using an API_KEY generated by an account. API_KEY generated at the site level does not return this information
+	AlertQuantity int `json:"alertQuantity"`
+
+	//  only available when using an API_KEY generated by an account. API_KEY generated at the site level does not return this information
+	// { NONE }
+	AlertSeverity string `json:"alertSeverity"`
+
+	Uris Uris `json:"uris"`
+
+	PublicSettings PublicSettings `json:"publicSettings"`
+}
+
+type DataPeriod struct {
+	// Precision: 2006-01-02 15:04:05 or nil if the site is not transmitting
+	StartDate Timestamp `json:"startDate"`
+
+	// Precision: 2006-01-02 15:04:05 or nil if the site is not transmitting
+	EndDate Timestamp `json:"endDate"`
+}
+
+type DataPeriod1 struct {
+	Id int `json:"id"`
+
+	// Precision: 2006-01-02 15:04:05 or nil if the site is not transmitting
+	StartDate Timestamp `json:"startDate"`
+
+	// Precision: 2006-01-02 15:04:05 or nil if the site is not transmitting
+	EndDate Timestamp `json:"endDate"`
+}
+
+// Site Data API
+
+type SiteList struct {
+	Count int    `json:"count"`
+	Site  []Site `json:"site"`
+}
+
+type SiteListResponse struct {
+	Sites SiteList `json:"sites"`
+}
+
+type SiteDetailsResponse struct {
+	Details Site `json:"details"`
+}
+
+type SiteDataPeriodResponse struct {
+	DataPeriod DataPeriod `json:"dataPeriod"`
+}
+
+type SitesDataPeriod struct {
+	Count          int           `json:"count"`
+	SiteEnergyList []DataPeriod1 `json:"siteEnergyList"`
+}
+
+type SiteDataPeriodBulkResponse struct {
+	DataPeriodList SitesDataPeriod `json:"dataPeriodList"`
+}
+
+// DateValue is a single measurement in a time series. Value is nil when no data was reported for the given date.
+type DateValue struct {
+	// Precision: 2006-01-02 15:04:05
+	Date Timestamp `json:"date"`
+
+	Value *float64 `json:"value"`
+}
+
+// MeasuredValues is the time series of a single site as returned by the bulk endpoints.
+type MeasuredValues struct {
+	MeasuredBy string      `json:"measuredBy"`
+	Values     []DateValue `json:"values"`
+}
+
+type SiteEnergy struct {
+	TimeUnit   string      `json:"timeUnit"`
+	Unit       string      `json:"unit"`
+	MeasuredBy string      `json:"measuredBy"`
+	Values     []DateValue `json:"values"`
+}
+
+type SiteEnergyResponse struct {
+	Energy SiteEnergy `json:"energy"`
+}
+
+type SiteEnergyValues struct {
+	SiteId       int            `json:"siteId"`
+	EnergyValues MeasuredValues `json:"energyValues"`
+}
+
+type SitesEnergy struct {
+	TimeUnit       string             `json:"timeUnit"`
+	Unit           string             `json:"unit"`
+	Count          int                `json:"count"`
+	SiteEnergyList []SiteEnergyValues `json:"siteEnergyList"`
+}
+
+type SiteEnergyBulkResponse struct {
+	SitesEnergy SitesEnergy `json:"sitesEnergy"`
+}
+
+type LifetimeEnergy struct {
+	// Precision: 2006-01-02
+	Date Timestamp `json:"date"`
+
+	Energy float64 `json:"energy"`
+	Unit   string  `json:"unit"`
+}
+
+type SiteEnergyTimePeriod struct {
+	Energy              float64        `json:"energy"`
+	Unit                string         `json:"unit"`
+	MeasuredBy          string         `json:"measuredBy"`
+	StartLifetimeEnergy LifetimeEnergy `json:"startLifetimeEnergy"`
+	EndLifetimeEnergy   LifetimeEnergy `json:"endLifetimeEnergy"`
+}
+
+type SiteEnergyTimePeriodResponse struct {
+	TimeFrameEnergy SiteEnergyTimePeriod `json:"timeFrameEnergy"`
+}
+
+type SiteEnergyTimePeriodValues struct {
+	SiteId          int                  `json:"siteId"`
+	TimeFrameEnergy SiteEnergyTimePeriod `json:"timeFrameEnergy"`
+}
+
+type SitesEnergyTimePeriod struct {
+	Count               int                          `json:"count"`
+	TimeFrameEnergyList []SiteEnergyTimePeriodValues `json:"timeFrameEnergyList"`
+}
+
+type SiteEnergyTimePeriodBulkResponse struct {
+	TimeFrameEnergyList SitesEnergyTimePeriod `json:"timeFrameEnergyList"`
+}
+
+type SitePower struct {
+	TimeUnit   string      `json:"timeUnit"`
+	Unit       string      `json:"unit"`
+	MeasuredBy string      `json:"measuredBy"`
+	Values     []DateValue `json:"values"`
+}
+
+type SitePowerResponse struct {
+	Power SitePower `json:"power"`
+}
+
+type SitePowerValues struct {
+	SiteId               int            `json:"siteId"`
+	PowerDataValueSeries MeasuredValues `json:"powerDataValueSeries"`
+}
+
+type SitesPower struct {
+	TimeUnit       string            `json:"timeUnit"`
+	Unit           string            `json:"unit"`
+	Count          int               `json:"count"`
+	SiteEnergyList []SitePowerValues `json:"siteEnergyList"`
+}
+
+type SitePowerBulkResponse struct {
+	PowerDateValuesList SitesPower `json:"powerDateValuesList"`
+}
+
+type EnergyRevenue struct {
+	Energy  float64 `json:"energy"`
+	Revenue float64 `json:"revenue"`
+}
+
+type CurrentPower struct {
+	Power float64 `json:"power"`
+}
+
+type SiteOverview struct {
+	// Precision: 2006-01-02 15:04:05
+	LastUpdateTime Timestamp `json:"lastUpdateTime"`
+
+	LifeTimeData  EnergyRevenue `json:"lifeTimeData"`
+	LastYearData  EnergyRevenue `json:"lastYearData"`
+	LastMonthData EnergyRevenue `json:"lastMonthData"`
+	LastDayData   EnergyRevenue `json:"lastDayData"`
+	CurrentPower  CurrentPower  `json:"currentPower"`
+	MeasuredBy    string        `json:"measuredBy"`
+}
+
+type SiteOverviewResponse struct {
+	Overview SiteOverview `json:"overview"`
+}
+
+type SiteOverviewValues struct {
+	SiteId       int          `json:"siteId"`
+	SiteOverview SiteOverview `json:"siteOverview"`
+}
+
+type SitesOverview struct {
+	Count          int                  `json:"count"`
+	SiteEnergyList []SiteOverviewValues `json:"siteEnergyList"`
+}
+
+type SiteOverviewBulkResponse struct {
+	SitesOverviews SitesOverview `json:"sitesOverviews"`
+}
+
+// MeterValues is the time series of a single meter (Production, Consumption, SelfConsumption, FeedIn or Purchased).
+type MeterValues struct {
+	Type   string      `json:"type"`
+	Values []DateValue `json:"values"`
+}
+
+type SitePowerDetailed struct {
+	TimeUnit string        `json:"timeUnit"`
+	Unit     string        `json:"unit"`
+	Meters   []MeterValues `json:"meters"`
+}
+
+type SitePowerDetailedResponse struct {
+	PowerDetails SitePowerDetailed `json:"powerDetails"`
+}
+
+type SiteEnergyDetailed struct {
+	TimeUnit string        `json:"timeUnit"`
+	Unit     string        `json:"unit"`
+	Meters   []MeterValues `json:"meters"`
+}
+
+type SiteEnergyDetailedResponse struct {
+	EnergyDetails SiteEnergyDetailed `json:"energyDetails"`
+}
+
+type PowerFlowElement struct {
+	// { Active | Idle | Disabled | Charging | Discharging }
+	Status string `json:"status"`
+
+	CurrentPower float64 `json:"currentPower"`
+
+	// only available for the STORAGE element
+	ChargeLevel *float64 `json:"chargeLevel,omitempty"`
+
+	// only available for the STORAGE element
+	Critical *bool `json:"critical,omitempty"`
+}
+
+// PowerFlowConnection is the direction of the power flow between two elements, e.g. from "GRID" to "Load".
+type PowerFlowConnection struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type SitePowerFlow struct {
+	UpdateRefreshRate int                   `json:"updateRefreshRate"`
+	Unit              string                `json:"unit"`
+	Connections       []PowerFlowConnection `json:"connections"`
+	Grid              *PowerFlowElement     `json:"GRID,omitempty"`
+	Load              *PowerFlowElement     `json:"LOAD,omitempty"`
+	PV                *PowerFlowElement     `json:"PV,omitempty"`
+	Storage           *PowerFlowElement     `json:"STORAGE,omitempty"`
+}
+
+type SitePowerFlowResponse struct {
+	SiteCurrentPowerFlow SitePowerFlow `json:"siteCurrentPowerFlow"`
+}
+
+type BatteryTelemetry struct {
+	// Precision: 2006-01-02 15:04:05
+	TimeStamp Timestamp `json:"timeStamp"`
+
+	Power                    *float64 `json:"power"`
+	BatteryState             int      `json:"batteryState"`
+	LifeTimeEnergyCharged    float64  `json:"lifeTimeEnergyCharged"`
+	LifeTimeEnergyDischarged float64  `json:"lifeTimeEnergyDischarged"`
+	FullPackEnergyAvailable  float64  `json:"fullPackEnergyAvailable"`
+	InternalTemp             float64  `json:"internalTemp"`
+	ACGridCharging           float64  `json:"ACGridCharging"`
+	StateOfCharge            *float64 `json:"stateOfCharge,omitempty"`
+}
+
+type Battery struct {
+	Nameplate      float64            `json:"nameplate"`
+	SerialNumber   string             `json:"serialNumber"`
+	ModelNumber    string             `json:"modelNumber"`
+	TelemetryCount int                `json:"telemetryCount"`
+	Telemetries    []BatteryTelemetry `json:"telemetries"`
+}
+
+type StorageInformation struct {
+	BatteryCount int       `json:"batteryCount"`
+	Batteries    []Battery `json:"batteries"`
+}
+
+type StorageInformationResponse struct {
+	StorageData StorageInformation `json:"storageData"`
+}
+
+type GasEmissionSaved struct {
+	Units string  `json:"units"`
+	CO2   float64 `json:"co2"`
+	SO2   float64 `json:"so2"`
+	NOX   float64 `json:"nox"`
+}
+
+type SiteEnvironmentalBenefits struct {
+	GasEmissionSaved GasEmissionSaved `json:"gasEmissionSaved"`
+	TreesPlanted     float64          `json:"treesPlanted"`
+	LightBulbs       float64          `json:"lightBulbs"`
+}
+
+type SiteEnvironmentalBenefitsResponse struct {
+	EnvBenefits SiteEnvironmentalBenefits `json:"envBenefits"`
+}
+
+// Site Equipment API
+
+type Component struct {
+	Name         string `json:"name"`
+	Manufacturer string `json:"manufacturer"`
+	Model        string `json:"model"`
+	SerialNumber string `json:"serialNumber"`
+}
+
+type ComponentsList struct {
+	Count int         `json:"count"`
+	List  []Component `json:"list"`
+}
+
+type ComponentsListResponse struct {
+	Reporters ComponentsList `json:"reporters"`
+}
+
+type InventoryMeter struct {
+	Name                       string `json:"name"`
+	Manufacturer               string `json:"manufacturer"`
+	Model                      string `json:"model"`
+	FirmwareVersion            string `json:"firmwareVersion"`
+	ConnectedSolaredgeDeviceSN string `json:"connectedSolaredgeDeviceSN"`
+
+	// { Production | Consumption | FeedIn | Purchased }
+	Type string `json:"type"`
+
+	// { physical | virtual }
+	Form string `json:"form"`
+}
+
+type InventorySensor struct {
+	ConnectedSolaredgeDeviceSN string `json:"connectedSolaredgeDeviceSN"`
+	Id                         string `json:"id"`
+	ConnectedTo                string `json:"connectedTo"`
+	Category                   string `json:"category"`
+	Type                       string `json:"type"`
+}
+
+type InventoryGateway struct {
+	Name            string `json:"name"`
+	SerialNumber    string `json:"serialNumber"`
+	FirmwareVersion string `json:"firmwareVersion"`
+}
+
+type InventoryBattery struct {
+	Name                string  `json:"name"`
+	Manufacturer        string  `json:"manufacturer"`
+	Model               string  `json:"model"`
+	FirmwareVersion     string  `json:"firmwareVersion"`
+	ConnectedInverterSn string  `json:"connectedInverterSn"`
+	NameplateCapacity   float64 `json:"nameplateCapacity"`
+	SerialNumber        string  `json:"SN"`
+}
+
+type InventoryInverter struct {
+	Name                string `json:"name"`
+	Manufacturer        string `json:"manufacturer"`
+	Model               string `json:"model"`
+	CommunicationMethod string `json:"communicationMethod"`
+	CpuVersion          string `json:"cpuVersion"`
+	SerialNumber        string `json:"SN"`
+	ConnectedOptimizers int    `json:"connectedOptimizers"`
+}
+
+type Inventory struct {
+	Meters    []InventoryMeter    `json:"meters"`
+	Sensors   []InventorySensor   `json:"sensors"`
+	Gateways  []InventoryGateway  `json:"gateways"`
+	Batteries []InventoryBattery  `json:"batteries"`
+	Inverters []InventoryInverter `json:"inverters"`
+}
+
+type InventoryResponse struct {
+	Inventory Inventory `json:"Inventory"`
+}
+
+// InverterPhaseData holds the AC measurements of a single phase.
+type InverterPhaseData struct {
+	AcCurrent     float64 `json:"acCurrent"`
+	AcVoltage     float64 `json:"acVoltage"`
+	AcFrequency   float64 `json:"acFrequency"`
+	ApparentPower float64 `json:"apparentPower"`
+	ActivePower   float64 `json:"activePower"`
+	ReactivePower float64 `json:"reactivePower"`
+	CosPhi        float64 `json:"cosPhi"`
+}
+
+type InverterTelemetry struct {
+	// Precision: 2006-01-02 15:04:05
+	Date Timestamp `json:"date"`
+
+	TotalActivePower      float64 `json:"totalActivePower"`
+	DcVoltage             float64 `json:"dcVoltage"`
+	GroundFaultResistance float64 `json:"groundFaultResistance"`
+	PowerLimit            float64 `json:"powerLimit"`
+	TotalEnergy           float64 `json:"totalEnergy"`
+	Temperature           float64 `json:"temperature"`
+
+	// { MPPT | THROTTLED | ... }
+	InverterMode string `json:"inverterMode"`
+
+	OperationMode int `json:"operationMode"`
+
+	// only available for three phase inverters
+	VL1To2 *float64 `json:"vL1To2,omitempty"`
+	VL2To3 *float64 `json:"vL2To3,omitempty"`
+	VL3To1 *float64 `json:"vL3To1,omitempty"`
+
+	L1Data InverterPhaseData  `json:"L1Data"`
+	L2Data *InverterPhaseData `json:"L2Data,omitempty"`
+	L3Data *InverterPhaseData `json:"L3Data,omitempty"`
+}
+
+type InverterTechnicalData struct {
+	Count       int                 `json:"count"`
+	Telemetries []InverterTelemetry `json:"telemetries"`
+}
+
+type InverterTechnicalDataResponse struct {
+	Data InverterTechnicalData `json:"data"`
+}
+
+type EquipmentChange struct {
+	SerialNumber string `json:"serialNumber"`
+	PartNumber   string `json:"partNumber"`
+
+	// Precision: 2006-01-02
+	Date Timestamp `json:"date"`
+}
+
+type EquipmentChangeLog struct {
+	Count int               `json:"count"`
+	List  []EquipmentChange `json:"list"`
+}
+
+type EquipmentChangeLogResponse struct {
+	ChangeLog EquipmentChangeLog `json:"ChangeLog"`
+}
+
+// Account List API
+
+type Account struct {
+	Id             int      `json:"id"`
+	Name           string   `json:"name"`
+	Location       Location `json:"location"`
+	CompanyWebSite string   `json:"companyWebSite"`
+	ContactPerson  string   `json:"contactPerson"`
+	Email          string   `json:"email"`
+	PhoneNumber    string   `json:"phoneNumber"`
+	FaxNumber      string   `json:"faxNumber"`
+	Notes          string   `json:"notes"`
+	ParentId       int      `json:"parentId"`
+	Uris           Uris     `json:"uris"`
+}
+
+type AccountList struct {
+	Count int       `json:"count"`
+	List  []Account `json:"list"`
+}
+
+type AccountListResponse struct {
+	Accounts AccountList `json:"accounts"`
+}
+
+// Meters API
+
+type MeterEnergy struct {
+	MeterSerialNumber          string      `json:"meterSerialNumber"`
+	ConnectedSolaredgeDeviceSN string      `json:"connectedSolaredgeDeviceSN"`
+	Model                      string      `json:"model"`
+	MeterType                  string      `json:"meterType"`
+	Values                     []DateValue `json:"values"`
+}
+
+type MetersData struct {
+	TimeUnit string        `json:"timeUnit"`
+	Unit     string        `json:"unit"`
+	Meters   []MeterEnergy `json:"meters"`
+}
+
+type MetersDataResponse struct {
+	MeterEnergyDetails MetersData `json:"meterEnergyDetails"`
+}
+
+// Sensors API
+
+type Sensor struct {
+	Name        string `json:"name"`
+	Measurement string `json:"measurement"`
+	Type        string `json:"type"`
+}
+
+// SensorGateway lists the sensors connected to a single gateway.
+type SensorGateway struct {
+	ConnectedTo string   `json:"connectedTo"`
+	Count       int      `json:"count"`
+	Sensors     []Sensor `json:"sensors"`
+}
+
+type SensorsList struct {
+	Count int             `json:"count"`
+	List  []SensorGateway `json:"list"`
+}
+
+type SensorsListResponse struct {
+	SiteSensors SensorsList `json:"SiteSensors"`
+}
+
+// SensorTelemetry only contains the measurements of the sensors that are installed, the others are nil.
+type SensorTelemetry struct {
+	// Precision: 2006-01-02 15:04:05
+	Date Timestamp `json:"date"`
+
+	AmbientTemperature           *float64 `json:"ambientTemperature,omitempty"`
+	ModuleTemperature            *float64 `json:"moduleTemperature,omitempty"`
+	WindSpeed                    *float64 `json:"windSpeed,omitempty"`
+	GlobalHorizontalIrradiance   *float64 `json:"globalHorizontalIrradiance,omitempty"`
+	DiffusedHorizontalIrradiance *float64 `json:"diffusedHorizontalIrradiance,omitempty"`
+	DirectNormalIrradiance       *float64 `json:"directNormalIrradiance,omitempty"`
+}
+
+// SensorGatewayData holds the telemetries of the sensors connected to a single gateway.
+type SensorGatewayData struct {
+	ConnectedTo string            `json:"connectedTo"`
+	Count       int               `json:"count"`
+	Telemetries []SensorTelemetry `json:"telemetries"`
+}
+
+type SensorData struct {
+	Data []SensorGatewayData `json:"data"`
+}
+
+type SensorDataResponse struct {
+	SiteSensors SensorData `json:"siteSensors"`
+}
+
+// API Versions
+
+type Version struct {
+	Release string `json:"release"`
+}
+
+type CurrentVersionResponse struct {
+	Version Version `json:"version"`
+}
+
+type SupportedVersionResponse struct {
+	Supported []Version `json:"supported"`
+}
@@ -0,0 +1,55 @@
+package golaredge
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// TestSiteDetailsDecode checks that a site, including its nested location, uris and public settings, decodes completely.
+func TestSiteDetailsDecode(t *testing.T) {
+	data := `{"details":{"id":1,"name":"site name","accountId":2,"status":"Active","peakPower":9.8,
+		"currency":"EUR","installationDate":"2012-08-16 00:00:00","ptoDate":null,"notes":"","type":"Optimizers & Inverters",
+		"location":{"country":"Belgium","city":"Ghent","address":"Street 1","zip":"9000","timeZone":"Europe/Brussels"},
+		"alertQuantity":0,"alertSeverity":"NONE","uris":{"IMAGE_URL":"site/1/siteImage/image.jpg","PUBLIC_URL":"site/1/public"},
+		"publicSettings":{"isPublic":true}}}`
+
+	var response SiteDetailsResponse
+
+	if err := json.Unmarshal([]byte(data), &response); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	site := response.Details
+
+	if site.Id != 1 || site.Name != "site name" || site.Location.TimeZone != "Europe/Brussels" || !site.PublicSettings.IsPublic || site.Uris.IMAGE_URL == "" {
+		t.Errorf("decoded site = %+v", site)
+	}
+
+	if !site.InstallationDate.Equal(time.Date(2012, 8, 16, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("InstallationDate = %v, want 2012-08-16", site.InstallationDate)
+	}
+
+	if !site.PtoDate.IsZero() {
+		t.Errorf("PtoDate = %v, want zero", site.PtoDate)
+	}
+}
+
+// TestDateValueDecode checks that both date layouts and null values are accepted.
+func TestDateValueDecode(t *testing.T) {
+	data := `[{"date":"2013-06-01 00:15:00","value":12.5},{"date":"2013-06-02","value":null}]`
+
+	var values []DateValue
+
+	if err := json.Unmarshal([]byte(data), &values); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if values[0].Value == nil || *values[0].Value != 12.5 || values[0].Date.Minute() != 15 {
+		t.Errorf("values[0] = %+v", values[0])
+	}
+
+	if values[1].Value != nil || values[1].Date.Day() != 2 {
+		t.Errorf("values[1] = %+v", values[1])
+	}
+}
@@ -0,0 +1,144 @@
+package golaredge
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+const (
+	DefaultMaxAttempts    int           = 3
+	DefaultInitialBackoff time.Duration = 500 * time.Millisecond
+	DefaultMaxBackoff     time.Duration = 30 * time.Second
+)
+
+// RetryPolicy configures the retries of transient failures: 5xx responses, timeouts and connection resets.
+// Every attempt goes through the rate limiter, so retries count towards the daily budget like any other request.
+// Zero values are replaced by the defaults above.
+type RetryPolicy struct {
+	// MaxAttempts is the number of attempts of a request, including the first one (default 3).
+	MaxAttempts int
+
+	// InitialBackoff is the wait before the first retry (default 500ms), it doubles with every retry up to MaxBackoff (default 30s).
+	// A random jitter of up to half the backoff is subtracted, so that clients failing together do not retry together.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+
+	// Budget is the maximum time from the first attempt until the start of a retry, 0 for no limit.
+	Budget time.Duration
+
+	// RetryTooManyRequests also retries the 429 responses that carry a Retry-After header, after waiting as long as requested.
+	RetryTooManyRequests bool
+}
+
+// WithRetry retries the requests failing with a transient error according to policy. By default requests are not retried.
+func WithRetry(policy RetryPolicy) Option {
+	if policy.MaxAttempts <= 0 {
+		policy.MaxAttempts = DefaultMaxAttempts
+	}
+
+	if policy.InitialBackoff <= 0 {
+		policy.InitialBackoff = DefaultInitialBackoff
+	}
+
+	if policy.MaxBackoff <= 0 {
+		policy.MaxBackoff = DefaultMaxBackoff
+	}
+
+	return func(client *Client) {
+		client.retry = &policy
+	}
+}
+
+// do calls attempt until it succeeds, fails with an error that is not transient or the policy gives up, and returns its last result.
+// If ctx is done while waiting for the next attempt, the error wraps ctx.Err() and that of the last attempt. A nil policy calls attempt once.
+func (policy *RetryPolicy) do(ctx context.Context, attempt func() ([]byte, error)) ([]byte, error) {
+	if policy == nil {
+		return attempt()
+	}
+
+	start := time.Now()
+	backoff := policy.InitialBackoff
+
+	for attempts := 1; ; attempts++ {
+		bytes, err := attempt()
+
+		if err == nil || attempts >= policy.MaxAttempts {
+			return bytes, err
+		}
+
+		retry, wait := policy.retryable(ctx, err)
+
+		if !retry {
+			return bytes, err
+		}
+
+		if wait == 0 {
+			wait = backoff - rand.N(backoff/2+1)
+			backoff = min(backoff*2, policy.MaxBackoff)
+		}
+
+		if policy.Budget > 0 && time.Since(start)+wait > policy.Budget {
+			return bytes, err
+		}
+
+		timer := time.NewTimer(wait)
+
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+
+			// the call ends because of ctx, not because of the failed attempt
+			return nil, errors.Join(ctx.Err(), err)
+		}
+	}
+}
+
+// retryable reports whether err is transient and how long the API asked to wait before retrying, 0 if it did not.
+func (policy *RetryPolicy) retryable(ctx context.Context, err error) (bool, time.Duration) {
+	// the error of a cancelled request is also a timeout
+	if ctx.Err() != nil {
+		return false, 0
+	}
+
+	var apiError *APIError
+
+	if errors.As(err, &apiError) {
+		switch apiError.Kind {
+		case ErrorKindServerError:
+			return true, 0
+		case ErrorKindQuotaExceeded, ErrorKindTooManyConcurrent:
+			return policy.RetryTooManyRequests && apiError.RetryAfter > 0, apiError.RetryAfter
+		default:
+			return false, 0
+		}
+	}
+
+	var netError net.Error
+
+	if errors.As(err, &netError) && netError.Timeout() {
+		return true, 0
+	}
+
+	return errors.Is(err, syscall.ECONNRESET) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF), 0
+}
+
+// retryAfter parses the Retry-After header, either a number of seconds or an http date. It returns 0 if the header is missing or invalid.
+func retryAfter(header string, now time.Time) time.Duration {
+	if seconds, err := strconv.Atoi(header); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(header); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+
+	return 0
+}
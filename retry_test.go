@@ -0,0 +1,145 @@
+package golaredge
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// TestClientRetry checks that server errors are retried and that every attempt counts towards the daily budget.
+func TestClientRetry(t *testing.T) {
+	requests := 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		if requests < 3 {
+			http.Error(w, "Internal error", http.StatusInternalServerError)
+
+			return
+		}
+
+		w.Write([]byte(`{"version": {"release": "1.0.0"}}`))
+	})
+	WithRetry(RetryPolicy{InitialBackoff: time.Millisecond})(client)
+
+	before := client.RemainingRequests()
+	version, err := client.GetCurrentVersion(t.Context())
+
+	if err != nil || version.Version.Release != "1.0.0" {
+		t.Fatalf("got %+v, %v", version, err)
+	}
+
+	if requests != 3 {
+		t.Errorf("got %d requests, want 3", requests)
+	}
+
+	if _, err := client.GetSiteOverview(t.Context(), SiteOverviewParams{SiteId: 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	if used := before - client.RemainingRequests(); used != 1 {
+		t.Errorf("used %d requests, want 1 (the version endpoints need no api key)", used)
+	}
+}
+
+// TestClientRetryGivesUp checks that client errors are not retried and that server errors are retried MaxAttempts times.
+func TestClientRetryGivesUp(t *testing.T) {
+	status := http.StatusBadRequest
+	requests := 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		http.Error(w, "error", status)
+	})
+	WithRetry(RetryPolicy{MaxAttempts: 4, InitialBackoff: time.Millisecond})(client)
+
+	before := client.RemainingRequests()
+
+	if _, err := client.GetSite(t.Context(), SiteParams{SiteId: 1}); !errors.Is(err, ErrBadRequest) || requests != 1 {
+		t.Errorf("got %d requests, %v", requests, err)
+	}
+
+	status = http.StatusServiceUnavailable
+	requests = 0
+
+	if _, err := client.GetSite(t.Context(), SiteParams{SiteId: 1}); !errors.Is(err, ErrServerError) || requests != 4 {
+		t.Errorf("got %d requests, %v", requests, err)
+	}
+
+	if used := before - client.RemainingRequests(); used != 5 {
+		t.Errorf("used %d requests, want 5", used)
+	}
+}
+
+// TestClientRetryAfter checks that a 429 is retried after the wait requested by the API only if the policy allows it.
+func TestClientRetryAfter(t *testing.T) {
+	requests := 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		if requests == 1 {
+			w.Header().Set("Retry-After", "1")
+			http.Error(w, "Too many requests", http.StatusTooManyRequests)
+
+			return
+		}
+
+		w.Write([]byte(`{"details": {"id": 1}}`))
+	})
+	WithRetry(RetryPolicy{Budget: 500 * time.Millisecond})(client)
+
+	if _, err := client.GetSite(t.Context(), SiteParams{SiteId: 1}); !errors.Is(err, ErrQuotaExceeded) || requests != 1 {
+		t.Errorf("got %d requests, %v, want no retry", requests, err)
+	}
+
+	requests = 0
+	WithRetry(RetryPolicy{RetryTooManyRequests: true})(client)
+	start := time.Now()
+
+	if site, err := client.GetSite(t.Context(), SiteParams{SiteId: 1}); err != nil || site.Details.Id != 1 || requests != 2 {
+		t.Errorf("got %+v, %v after %d requests", site, err, requests)
+	}
+
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("retried after %s, want 1s", elapsed)
+	}
+}
+
+// TestClientRetryCancelled checks that a call whose context ends while waiting for the next attempt fails with the error of the context.
+func TestClientRetryCancelled(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Internal error", http.StatusInternalServerError)
+	})
+	WithRetry(RetryPolicy{InitialBackoff: time.Second})(client)
+	WithMiddleware(TimeoutMiddleware(50 * time.Millisecond))(client)
+
+	start := time.Now()
+	_, err := client.GetSite(t.Context(), SiteParams{SiteId: 1})
+
+	if !errors.Is(err, context.DeadlineExceeded) || !errors.Is(err, ErrServerError) {
+		t.Errorf("error = %v, want context.DeadlineExceeded and the server error of the last attempt", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("returned after %s, want it to stop waiting once the context is done", elapsed)
+	}
+}
+
+// TestRetryAfter checks the parsing of both forms of the Retry-After header.
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	tests := map[string]time.Duration{
+		"120":                           2 * time.Minute,
+		"Wed, 01 May 2024 12:00:30 GMT": 30 * time.Second,
+		"Wed, 01 May 2024 11:00:00 GMT": 0,
+		"":                              0,
+		"soon":                          0,
+	}
+
+	for header, want := range tests {
+		if got := retryAfter(header, now); got != want {
+			t.Errorf("retryAfter(%q) = %s, want %s", header, got, want)
+		}
+	}
+}
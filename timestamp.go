@@ -0,0 +1,123 @@
+package golaredge
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"time"
+)
+
+const (
+	dateLayout     string = "2006-01-02"
+	dateTimeLayout string = "2006-01-02 15:04:05"
+)
+
+// encodeDate formats a date-only parameter (e.g. startDate of the energy endpoint). The date is the calendar date of t in its own location,
+// the client converts the dates to the time zone of the site before building the request, like the times (see encodeDateTime).
+func encodeDate(t time.Time) string {
+	return t.Format(dateLayout)
+}
+
+// encodeDateTime formats a date-time parameter (e.g. startTime of the power endpoint) as the wall clock of t in its own location.
+// The API interprets it in the time zone of the site, the client converts the times before building the request (see Client.SiteTimeZone).
+func encodeDateTime(t time.Time) string {
+	return t.Format(dateTimeLayout)
+}
+
+// Timestamp is a date or date-time as returned by the API, either "2006-01-02 15:04:05" or "2006-01-02".
+// The API returns null for dates that are not available (e.g. a site that is not transmitting), which decodes to the zero Timestamp.
+// The API returns the wall clock of the site without any offset, so a decoded Timestamp is in UTC until it is moved to the
+// time zone of the site with InLocation. The client does so for every response of a site with a known time zone.
+type Timestamp struct {
+	time.Time
+}
+
+// InLocation returns the timestamp with the same wall clock in location, e.g. 2024-03-31 03:00:00 UTC becomes 2024-03-31 03:00:00 CEST.
+func (timestamp Timestamp) InLocation(location *time.Location) Timestamp {
+	if timestamp.IsZero() {
+		return timestamp
+	}
+
+	year, month, day := timestamp.Date()
+	hour, minute, second := timestamp.Clock()
+
+	return Timestamp{time.Date(year, month, day, hour, minute, second, timestamp.Nanosecond(), location)}
+}
+
+var timestampType = reflect.TypeFor[Timestamp]()
+
+// localizeTimestamps moves every Timestamp reachable from value (a pointer to a response) to location, see Timestamp.InLocation.
+func localizeTimestamps(value any, location *time.Location) {
+	localizeValue(reflect.ValueOf(value), location)
+}
+
+func localizeValue(value reflect.Value, location *time.Location) {
+	switch value.Kind() {
+	case reflect.Pointer:
+		if !value.IsNil() {
+			localizeValue(value.Elem(), location)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := range value.Len() {
+			localizeValue(value.Index(i), location)
+		}
+	case reflect.Struct:
+		if value.Type() == timestampType {
+			if value.CanSet() {
+				value.Set(reflect.ValueOf(value.Interface().(Timestamp).InLocation(location)))
+			}
+
+			return
+		}
+
+		for i := range value.NumField() {
+			if value.Type().Field(i).IsExported() {
+				localizeValue(value.Field(i), location)
+			}
+		}
+	}
+}
+
+func (timestamp *Timestamp) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*timestamp = Timestamp{}
+
+		return nil
+	}
+
+	var value string
+
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	if value == "" {
+		*timestamp = Timestamp{}
+
+		return nil
+	}
+
+	layout := dateTimeLayout
+
+	if len(value) == len(dateLayout) {
+		layout = dateLayout
+	}
+
+	parsed, err := time.Parse(layout, value)
+
+	if err != nil {
+		return err
+	}
+
+	timestamp.Time = parsed
+
+	return nil
+}
+
+func (timestamp Timestamp) MarshalJSON() ([]byte, error) {
+	if timestamp.IsZero() {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(timestamp.Format(dateTimeLayout))
+}
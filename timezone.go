@@ -0,0 +1,124 @@
+package golaredge
+
+import (
+	"context"
+	"strconv"
+	"time"
+)
+
+// SetSiteTimeZone sets the time zone of a site, which is otherwise learned from its details (GetSite, GetSiteList, Sites).
+func (client *Client) SetSiteTimeZone(siteId int, location *time.Location) {
+	client.timeZoneMutex.Lock()
+	defer client.timeZoneMutex.Unlock()
+
+	client.timeZones[siteId] = location
+}
+
+// SiteTimeZone returns the time zone of a site, if known. The client converts the date and date-time parameters of the requests
+// of a site with a known time zone (and of the bulk requests of sites sharing it) to the wall clock of the site and returns every timestamp of the response in that time zone.
+func (client *Client) SiteTimeZone(siteId int) (*time.Location, bool) {
+	client.timeZoneMutex.RLock()
+	defer client.timeZoneMutex.RUnlock()
+
+	location, ok := client.timeZones[siteId]
+
+	return location, ok
+}
+
+// WithDefaultTimeZone sets the time zone of the sites with an unknown time zone, see SiteTimeZone.
+func WithDefaultTimeZone(location *time.Location) Option {
+	return func(client *Client) {
+		client.defaultTimeZone = location
+	}
+}
+
+// siteTimeZone returns the time zone of a site, or the default time zone if it is unknown.
+func (client *Client) siteTimeZone(siteId int) (*time.Location, bool) {
+	if location, ok := client.SiteTimeZone(siteId); ok {
+		return location, true
+	}
+
+	return client.defaultTimeZone, client.defaultTimeZone != nil
+}
+
+// rememberTimeZones records the IANA time zone of the location of every site. Sites with an unknown time zone are ignored.
+func (client *Client) rememberTimeZones(sites []Site) {
+	for i := range sites {
+		if sites[i].Location.TimeZone == "" {
+			continue
+		}
+
+		location, err := time.LoadLocation(sites[i].Location.TimeZone)
+
+		if err != nil {
+			continue
+		}
+
+		client.SetSiteTimeZone(sites[i].Id, location)
+	}
+}
+
+// siteTimes converts start and end to the time zone of the site (or the default time zone), if known, so that they are encoded as the wall clock of the site.
+func (client *Client) siteTimes(siteId int, start time.Time, end time.Time) (time.Time, time.Time) {
+	location, ok := client.siteTimeZone(siteId)
+
+	if !ok {
+		return start, end
+	}
+
+	return start.In(location), end.In(location)
+}
+
+// sitesTimes is siteTimes for the sites of a bulk request, which share a single range: start and end are converted
+// only if every site has the same known time zone, as the API interprets them in the time zone of each site.
+func (client *Client) sitesTimes(siteIds []int, start time.Time, end time.Time) (time.Time, time.Time) {
+	var shared *time.Location
+
+	for _, siteId := range siteIds {
+		location, ok := client.siteTimeZone(siteId)
+
+		if !ok || (shared != nil && location.String() != shared.String()) {
+			return start, end
+		}
+
+		shared = location
+	}
+
+	if shared == nil {
+		return start, end
+	}
+
+	return start.In(shared), end.In(shared)
+}
+
+// siteNow returns the current time in the time zone of the site of request (or the default time zone). If it is unknown, e.g. for the
+// requests of several sites, it returns the time a day ago in UTC, as the date of a site west of UTC may still be that of yesterday.
+func (client *Client) siteNow(request Request) time.Time {
+	now := client.now()
+
+	if siteId, err := strconv.Atoi(request.PathParams["siteId"]); err == nil {
+		if location, ok := client.siteTimeZone(siteId); ok {
+			return now.In(location)
+		}
+	}
+
+	return now.UTC().AddDate(0, 0, -1)
+}
+
+// localize moves the timestamps of a response of the site to its time zone, if known.
+func (client *Client) localize(siteId int, response any) {
+	if location, ok := client.siteTimeZone(siteId); ok {
+		localizeTimestamps(response, location)
+	}
+}
+
+// fetchSite is fetch for an endpoint of a single site, returning the timestamps in the time zone of the site.
+func fetchSite[T any](ctx context.Context, client *Client, siteId int, request Request, params any, err error) (T, error) {
+	result, err := fetch[T](ctx, client, request, params, err)
+
+	if err == nil {
+		client.localize(siteId, &result)
+	}
+
+	return result, err
+}
@@ -0,0 +1,137 @@
+package golaredge
+
+import (
+	"maps"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// TestClientSiteTimeZone checks that the times of a request are sent as the wall clock of the site
+// and that the returned timestamps are in its time zone, around the DST change of March 31st 2024 in Brussels.
+func TestClientSiteTimeZone(t *testing.T) {
+	brussels, err := time.LoadLocation("Europe/Brussels")
+
+	if err != nil {
+		t.Skipf("time zone database not available: %v", err)
+	}
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/site/1/details":
+			w.Write([]byte(`{"details":{"id":1,"location":{"timeZone":"Europe/Brussels"}}}`))
+		case "/site/1/power":
+			if start, end := r.URL.Query().Get("startTime"), r.URL.Query().Get("endTime"); start != "2024-03-31 00:00:00" || end != "2024-03-31 04:00:00" {
+				t.Errorf("startTime = %q, endTime = %q", start, end)
+			}
+
+			w.Write([]byte(`{"power":{"values":[{"date":"2024-03-31 01:45:00","value":1},{"date":"2024-03-31 03:00:00","value":2}]}}`))
+		}
+	})
+
+	if _, err := client.GetSite(t.Context(), SiteParams{SiteId: 1}); err != nil {
+		t.Fatalf("GetSite() error = %v", err)
+	}
+
+	if location, ok := client.SiteTimeZone(1); !ok || location.String() != "Europe/Brussels" {
+		t.Fatalf("SiteTimeZone(1) = %v, %v", location, ok)
+	}
+
+	response, err := client.GetSitePower(t.Context(), SitePowerParams{
+		SiteId:    1,
+		StartTime: time.Date(2024, 3, 30, 23, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2024, 3, 31, 2, 0, 0, 0, time.UTC),
+	})
+
+	if err != nil {
+		t.Fatalf("GetSitePower() error = %v", err)
+	}
+
+	values := response.Power.Values
+
+	// 01:45 CET and 03:00 CEST are only 15 minutes apart
+	if values[0].Date.Location().String() != brussels.String() || values[1].Date.Sub(values[0].Date.Time) != 15*time.Minute {
+		t.Errorf("values = %v, %v", values[0].Date, values[1].Date)
+	}
+}
+
+// TestEncodeDate checks the formats of date-only and date-time parameters.
+func TestEncodeDate(t *testing.T) {
+	date := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	if encoded := encodeDate(date); encoded != "2024-01-02" {
+		t.Errorf("encodeDate() = %q", encoded)
+	}
+
+	if encoded := encodeDateTime(date); encoded != "2024-01-02 03:04:05" {
+		t.Errorf("encodeDateTime() = %q", encoded)
+	}
+}
+
+// TestClientDefaultTimeZone checks that the default time zone applies to the sites with an unknown time zone only.
+func TestClientDefaultTimeZone(t *testing.T) {
+	brussels, err := time.LoadLocation("Europe/Brussels")
+
+	if err != nil {
+		t.Skipf("time zone database not available: %v", err)
+	}
+
+	startTimes := []string{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		startTimes = append(startTimes, r.URL.Query().Get("startTime"))
+		w.Write([]byte(`{"power":{}}`))
+	})
+	WithDefaultTimeZone(brussels)(client)
+	client.SetSiteTimeZone(2, time.UTC)
+
+	start := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	for _, siteId := range []int{1, 2} {
+		if _, err := client.GetSitePower(t.Context(), SitePowerParams{SiteId: siteId, StartTime: start, EndTime: start.Add(time.Hour)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(startTimes) != 2 || startTimes[0] != "2024-06-01 12:00:00" || startTimes[1] != "2024-06-01 10:00:00" {
+		t.Errorf("startTime = %q", startTimes)
+	}
+}
+
+// TestClientSiteTimeZoneDates checks that the dates of a request are sent as the date at the site, and that those of a bulk request
+// are converted only if all of its sites share a time zone.
+func TestClientSiteTimeZoneDates(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+
+	if err != nil {
+		t.Skipf("time zone database not available: %v", err)
+	}
+
+	dates := map[string]string{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		dates[r.URL.Path] = r.URL.Query().Get("startDate")
+		w.Write([]byte(`{}`))
+	})
+	client.SetSiteTimeZone(1, tokyo)
+	client.SetSiteTimeZone(2, tokyo)
+
+	// June 2 in Tokyo
+	start := time.Date(2024, 6, 1, 20, 0, 0, 0, time.UTC)
+
+	if _, err := client.GetSiteEnergy(t.Context(), SiteEnergyParams{SiteId: 1, StartDate: start, EndDate: start}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.GetSiteEnergyTimePeriodBulk(t.Context(), SiteEnergyTimePeriodBulkParams{SiteIds: []int{1, 2}, StartDate: start, EndDate: start}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.GetSiteEnergyBulk(t.Context(), SiteEnergyBulkParams{SiteIds: []int{1, 3}, StartDate: start, EndDate: start}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]string{"/site/1/energy": "2024-06-02", "/sites/1,2/timeFrameEnergy": "2024-06-02", "/sites/1,3/energy": "2024-06-01"}
+
+	if !maps.Equal(dates, want) {
+		t.Errorf("startDate = %v, want %v", dates, want)
+	}
+}
@@ -0,0 +1,11 @@
+package golaredge
+
+type Uris struct {
+	PUBLIC_URL      string `json:"PUBLIC_URL,omitempty"`
+	IMAGE_URL       string `json:"IMAGE_URL,omitempty"`
+	SITE_IMAGE      string `json:"SITE_IMAGE,omitempty"`
+	INSTALLER_IMAGE string `json:"INSTALLER_IMAGE,omitempty"`
+	DATA_PERIOD     string `json:"DATA_PERIOD,omitempty"`
+	DETAILS         string `json:"DETAILS,omitempty"`
+	OVERVIEW        string `json:"OVERVIEW,omitempty"`
+}
@@ -0,0 +1,176 @@
+package golaredge
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ValidationMode decides what happens with parameters the API does not accept but that a request can be sent without,
+// e.g. a page size above 100 or an unknown meter. Parameters a request cannot be sent without (e.g. a missing date) always fail.
+type ValidationMode int
+
+const (
+	// ValidationLenient drops the invalid parameters and reports them as warnings, see WithValidationWarnings.
+	// The Get*Request functions always validate leniently, without reporting the warnings.
+	ValidationLenient ValidationMode = iota
+
+	// ValidationStrict fails the call with a ValidationError listing every invalid parameter.
+	// Valid parameters that are likely a mistake (see ErrFutureDates) are still only reported as warnings.
+	ValidationStrict
+)
+
+// FieldError is an invalid parameter: the field of the params struct, its value and why it is invalid.
+// Err is the sentinel error of the problem (e.g. ErrMissingDates), or ErrIgnoredParameter for a parameter the lenient mode drops.
+type FieldError struct {
+	Field  string
+	Value  any
+	Reason string
+	Err    error
+}
+
+func (fieldError *FieldError) Error() string {
+	if fieldError.Value == nil {
+		return fmt.Sprintf("%s: %s", fieldError.Field, fieldError.Reason)
+	}
+
+	return fmt.Sprintf("%s %v: %s", fieldError.Field, fieldError.Value, fieldError.Reason)
+}
+
+func (fieldError *FieldError) Unwrap() error {
+	return fieldError.Err
+}
+
+// ValidationError lists every invalid parameter of a request. errors.Is matches the sentinel errors of all fields.
+type ValidationError struct {
+	Fields []*FieldError
+}
+
+func (validationError *ValidationError) Error() string {
+	messages := make([]string, len(validationError.Fields))
+
+	for i := range validationError.Fields {
+		messages[i] = validationError.Fields[i].Error()
+	}
+
+	return fmt.Sprintf("invalid parameters: %s", strings.Join(messages, "; "))
+}
+
+func (validationError *ValidationError) Unwrap() []error {
+	errs := make([]error, len(validationError.Fields))
+
+	for i := range validationError.Fields {
+		errs[i] = validationError.Fields[i]
+	}
+
+	return errs
+}
+
+// WithValidation sets the validation mode of the client, ValidationLenient by default.
+func WithValidation(mode ValidationMode) Option {
+	return func(client *Client) {
+		client.validationMode = mode
+	}
+}
+
+// WithValidationWarnings sets a function receiving the parameters the lenient mode dropped from a request, before it is sent.
+// It also receives the warnings of both modes about valid parameters, e.g. ErrFutureDates.
+func WithValidationWarnings(handler func(warnings *ValidationError)) Option {
+	return func(client *Client) {
+		client.validationWarnings = handler
+	}
+}
+
+// validation collects the invalid parameters found while building a request.
+type validation struct {
+	// now is the current time of the client, to check ranges against.
+	now time.Time
+
+	errors   []*FieldError
+	warnings []*FieldError
+	notes    []*FieldError
+}
+
+// fail records a parameter the request cannot be sent without.
+func (validation *validation) fail(field string, value any, err error, reason string) {
+	validation.errors = append(validation.errors, &FieldError{Field: field, Value: value, Reason: reason, Err: err})
+}
+
+// ignore records a parameter that is dropped from the request, or sent although the API ignores it.
+func (validation *validation) ignore(field string, value any, reason string) {
+	validation.warnings = append(validation.warnings, &FieldError{Field: field, Value: value, Reason: reason, Err: ErrIgnoredParameter})
+}
+
+// warn records a valid parameter that is likely a mistake. It is a warning in strict mode too.
+func (validation *validation) warn(field string, value any, err error, reason string) {
+	validation.notes = append(validation.notes, &FieldError{Field: field, Value: value, Reason: reason, Err: err})
+}
+
+func (validation *validation) failed() bool {
+	return len(validation.errors) > 0
+}
+
+// result returns the warnings and the error of the request in mode. In strict mode the ignored parameters are part of the error.
+func (validation *validation) result(mode ValidationMode) (*ValidationError, error) {
+	fields := validation.errors
+
+	if mode == ValidationStrict {
+		fields = append(fields, validation.warnings...)
+	}
+
+	if len(fields) > 0 {
+		return nil, &ValidationError{Fields: fields}
+	}
+
+	if warnings := slices.Concat(validation.warnings, validation.notes); len(warnings) > 0 {
+		return &ValidationError{Fields: warnings}, nil
+	}
+
+	return nil, nil
+}
+
+// requestBuilder builds a request, recording every invalid parameter in validation.
+type requestBuilder[P any] func(params P, validation *validation) Request
+
+// buildRequest builds a request in mode at time now, returning the dropped parameters as warnings (lenient mode only).
+func buildRequest[P any](builder requestBuilder[P], params P, apiKey string, mode ValidationMode, now time.Time) (Request, *ValidationError, error) {
+	validation := &validation{now: now}
+	request := builder(params, validation)
+
+	if apiKey == "" && request.Auth == AuthAPIKey {
+		validation.fail("apiKey", nil, ErrMissingAPIKey, ErrMissingAPIKey.Error())
+	}
+
+	warnings, err := validation.result(mode)
+
+	if err != nil {
+		return Request{}, nil, err
+	}
+
+	return request, warnings, nil
+}
+
+// lenientRequest builds a request leniently for the Get*Request functions. The request does not hold the api key:
+// its String form is safe to log, the url to send is request.AuthenticatedURL(apiKey).
+func lenientRequest[P any](builder requestBuilder[P], params P) (Request, error) {
+	validation := &validation{now: time.Now()}
+	request := builder(params, validation)
+
+	if _, err := validation.result(ValidationLenient); err != nil {
+		return Request{}, err
+	}
+
+	return request, nil
+}
+
+// clientRequest is buildRequest in the validation mode of the client, passing the warnings to its handler.
+func clientRequest[P any](client *Client, builder requestBuilder[P], params P) (Request, error) {
+	request, warnings, err := buildRequest(builder, params, client.apiKey, client.validationMode, client.now())
+
+	if warnings != nil && client.validationWarnings != nil {
+		client.validationWarnings(warnings)
+	}
+
+	return request, err
+}
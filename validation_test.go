@@ -0,0 +1,111 @@
+package golaredge
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// TestStrictValidation checks that the strict mode fails with every invalid parameter and nothing is sent.
+func TestStrictValidation(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s", r.URL)
+	})
+	WithValidation(ValidationStrict)(client)
+
+	size := 200
+	startIndex := -1
+	_, err := client.GetSiteList(t.Context(), SiteListParams{Size: &size, StartIndex: &startIndex, Status: []SiteStatus{SiteStatusActive, SiteStatus(9)}})
+
+	var validationError *ValidationError
+
+	if !errors.As(err, &validationError) {
+		t.Fatalf("GetSiteList() error = %v, want *ValidationError", err)
+	}
+
+	fields := []string{}
+
+	for i := range validationError.Fields {
+		fields = append(fields, validationError.Fields[i].Field)
+	}
+
+	if len(fields) != 3 || fields[0] != "Size" || fields[1] != "StartIndex" || fields[2] != "Status" {
+		t.Errorf("fields = %v, want [Size StartIndex Status]", fields)
+	}
+
+	if !errors.Is(err, ErrIgnoredParameter) {
+		t.Errorf("error = %v, want ErrIgnoredParameter", err)
+	}
+}
+
+// TestLenientValidationWarnings checks that the lenient mode sends the request without the invalid parameters and reports them.
+func TestLenientValidationWarnings(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("meters") != "Production" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+
+		w.Write([]byte(`{"meterEnergyDetails":{}}`))
+	})
+
+	var warnings *ValidationError
+
+	WithValidationWarnings(func(validationWarnings *ValidationError) {
+		warnings = validationWarnings
+	})(client)
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	if _, err := client.GetMetersData(t.Context(), MetersDataParams{SiteId: 1, StartTime: start, EndTime: start.AddDate(0, 0, 1), Meters: []MeterType{MeterProduction, MeterType(42)}}); err != nil {
+		t.Fatalf("GetMetersData() error = %v", err)
+	}
+
+	if warnings == nil || len(warnings.Fields) != 1 || warnings.Fields[0].Field != "Meters" || warnings.Fields[0].Value != MeterType(42) {
+		t.Errorf("warnings = %v, want the unknown meter", warnings)
+	}
+}
+
+// TestValidationErrorFields checks that the errors of all fields are reported at once.
+func TestValidationErrorFields(t *testing.T) {
+	_, _, err := buildRequest(inverterTechnicalDataRequest, InverterTechnicalDataParams{SiteId: -1}, "", ValidationLenient, time.Now())
+
+	for _, want := range []error{ErrInvalidSiteID, ErrMissingSerialNumber, ErrMissingDates, ErrMissingAPIKey} {
+		if !errors.Is(err, want) {
+			t.Errorf("error = %v, want %v", err, want)
+		}
+	}
+
+	width := 100
+	hash := 1
+
+	if _, _, err := buildRequest(siteImageRequest, SiteImageParams{SiteId: 1, MaxWidth: &width, Hash: &hash}, "key", ValidationStrict, time.Now()); !errors.Is(err, ErrIgnoredParameter) {
+		t.Errorf("error = %v, want the hash conflict", err)
+	}
+}
+
+// TestValidationClock checks that a range starting after the time of the client clock is reported as a warning, even in strict mode, and only then.
+func TestValidationClock(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"power":{}}`))
+	})
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	params := SitePowerParams{SiteId: 1, StartTime: start, EndTime: start.Add(time.Hour)}
+
+	var warnings *ValidationError
+
+	WithValidation(ValidationStrict)(client)
+	WithValidationWarnings(func(w *ValidationError) { warnings = w })(client)
+	WithClock(func() time.Time { return start.Add(-time.Minute) })(client)
+
+	if _, err := client.GetSitePower(t.Context(), params); err != nil || warnings == nil || !errors.Is(warnings, ErrFutureDates) {
+		t.Errorf("GetSitePower() error = %v, warnings = %v, want the start in the future reported as a warning", err, warnings)
+	}
+
+	warnings = nil
+	WithClock(func() time.Time { return start })(client)
+
+	if _, err := client.GetSitePower(t.Context(), params); err != nil || warnings != nil {
+		t.Errorf("GetSitePower() error = %v, warnings = %v", err, warnings)
+	}
+}
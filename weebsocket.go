@@ -0,0 +1,141 @@
+package golaredge
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+const baseUri string = "https://monitoringapi.solaredge.com/"
+
+func (client *Client) get(ctx context.Context, request Request) ([]byte, error) {
+	if client.err != nil {
+		return nil, client.err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	key := request.Canonical()
+	cacheKey := client.cacheKey(request)
+	ttl, cacheable := cacheTTL(client.cacheTTLs, request, client.siteNow(request))
+	cacheable = cacheable && client.cache != nil
+
+	if cacheable {
+		if bytes, ok := client.cache.Get(cacheKey); ok {
+			return bytes, nil
+		}
+	}
+
+	return client.coalescer.do(ctx, key, func(ctx context.Context) ([]byte, error) {
+		bytes, err := client.retry.do(ctx, func() ([]byte, error) {
+			return client.send(ctx, request)
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		if cacheable {
+			client.cache.Set(cacheKey, bytes, ttl)
+		}
+
+		return bytes, nil
+	})
+}
+
+// send executes a single attempt of request.
+func (client *Client) send(ctx context.Context, request Request) ([]byte, error) {
+	if client.limiter != nil {
+		apiKey := ""
+
+		if request.Auth == AuthAPIKey {
+			apiKey = client.apiKey
+		}
+
+		release, err := client.limiter.acquire(ctx, apiKey, siteIdsFromPath(request.Path()))
+
+		if err != nil {
+			return nil, err
+		}
+
+		defer release()
+	}
+
+	done := client.logRequest(ctx, request)
+	statusCode, bytes, err := client.roundTrip(ctx, request)
+	err = redactError(err)
+	done(statusCode, len(bytes), err)
+
+	return bytes, err
+}
+
+// roundTrip sends request and returns the status code and body of the response.
+func (client *Client) roundTrip(ctx context.Context, request Request) (int, []byte, error) {
+	requestUrl, err := request.url(client.baseUrl, client.apiKey)
+
+	if err != nil {
+		return 0, nil, err
+	}
+
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodGet, requestUrl, nil)
+
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if client.userAgent != "" {
+		httpRequest.Header.Set("User-Agent", client.userAgent)
+	}
+
+	response, err := client.httpClient.Do(httpRequest)
+
+	if err != nil {
+		return 0, nil, err
+	}
+
+	body := response.Body
+
+	defer body.Close()
+
+	bytes, err := io.ReadAll(body)
+
+	if err != nil {
+		return response.StatusCode, nil, err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		apiError := newAPIError(response.StatusCode, request.Path(), bytes)
+		apiError.RetryAfter = retryAfter(response.Header.Get("Retry-After"), client.now())
+
+		// without a Retry-After the quota is used until the next day
+		if apiError.Kind == ErrorKindQuotaExceeded && apiError.RetryAfter == 0 && client.limiter != nil {
+			client.limiter.exhaust(client.apiKey)
+		}
+
+		return response.StatusCode, nil, apiError
+	}
+
+	return response.StatusCode, bytes, nil
+}
+
+// fetch executes request built from params through the middlewares and decodes the JSON response body into T.
+// err is the error returned by the builder, so that callers can pass its results straight through.
+func fetch[T any](ctx context.Context, client *Client, request Request, params any, err error) (T, error) {
+	return execute(ctx, client, request, params, err, func(bytes []byte) (T, error) {
+		var result T
+
+		err := json.Unmarshal(bytes, &result)
+
+		return result, err
+	})
+}
+
+// fetchBytes is fetch for the endpoints returning an image, it returns the raw response body.
+func fetchBytes(ctx context.Context, client *Client, request Request, params any, err error) ([]byte, error) {
+	return execute(ctx, client, request, params, err, func(bytes []byte) ([]byte, error) {
+		return bytes, nil
+	})
+}